@@ -0,0 +1,83 @@
+package conventionalcommit
+
+import "bytes"
+
+// AutosquashAction is a git autosquash prefix, as created by "git commit
+// --fixup" and "git commit --squash".
+type AutosquashAction string
+
+const (
+	// AutosquashFixup is the "fixup! " prefix, which squashes the commit into
+	// its target, discarding the commit's message.
+	AutosquashFixup AutosquashAction = "fixup"
+
+	// AutosquashSquash is the "squash! " prefix, which squashes the commit into
+	// its target, combining both commit messages.
+	AutosquashSquash AutosquashAction = "squash"
+
+	// AutosquashAmend is the "amend! " prefix, which squashes the commit into
+	// its target, replacing the target's commit message.
+	AutosquashAmend AutosquashAction = "amend"
+)
+
+var autosquashPrefixes = []AutosquashAction{
+	AutosquashFixup,
+	AutosquashSquash,
+	AutosquashAmend,
+}
+
+// Autosquash describes a commit which "git rebase --autosquash" will squash
+// into an earlier commit.
+type Autosquash struct {
+	// Actions is the list of autosquash prefixes on the first line, in the
+	// order they appear. Stacked prefixes like "fixup! fixup! " occur when
+	// fixing up a fixup commit.
+	Actions []AutosquashAction
+
+	// Target is the header (first line) of the targeted commit, which is the
+	// remainder of the first line after all prefixes. Git also allows this to
+	// be a commit hash.
+	Target string
+}
+
+// Autosquash returns details of the git autosquash prefixes on the first line
+// of the message, or nil if there are none.
+func (s *RawMessage) Autosquash() *Autosquash {
+	if len(s.Paragraphs) == 0 {
+		return nil
+	}
+
+	header := s.Paragraphs[0].Lines[0].Content
+
+	r := &Autosquash{Actions: []AutosquashAction{}}
+	for {
+		action, rest := trimAutosquashPrefix(header)
+		if action == "" {
+			break
+		}
+
+		r.Actions = append(r.Actions, action)
+		header = rest
+	}
+
+	if len(r.Actions) == 0 {
+		return nil
+	}
+
+	r.Target = string(header)
+
+	return r
+}
+
+// trimAutosquashPrefix returns the autosquash action of the prefix at the start
+// of the given header, and the header without the prefix.
+func trimAutosquashPrefix(header []byte) (AutosquashAction, []byte) {
+	for _, action := range autosquashPrefixes {
+		prefix := []byte(string(action) + "! ")
+		if bytes.HasPrefix(header, prefix) {
+			return action, header[len(prefix):]
+		}
+	}
+
+	return "", header
+}
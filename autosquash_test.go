@@ -0,0 +1,85 @@
+package conventionalcommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawMessage_Autosquash(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    *Autosquash
+	}{
+		{
+			name:    "empty",
+			message: "",
+			want:    nil,
+		},
+		{
+			name:    "regular",
+			message: "fix: a fixup! of a broken thing",
+			want:    nil,
+		},
+		{
+			name:    "missing space",
+			message: "fixup!fix: a broken thing",
+			want:    nil,
+		},
+		{
+			name:    "fixup",
+			message: "fixup! fix: a broken thing",
+			want: &Autosquash{
+				Actions: []AutosquashAction{AutosquashFixup},
+				Target:  "fix: a broken thing",
+			},
+		},
+		{
+			name:    "squash",
+			message: "squash! fix: a broken thing\n\nMore details.",
+			want: &Autosquash{
+				Actions: []AutosquashAction{AutosquashSquash},
+				Target:  "fix: a broken thing",
+			},
+		},
+		{
+			name: "amend",
+			message: "amend! fix: a broken thing\n\n" +
+				"fix: a broken thing properly",
+			want: &Autosquash{
+				Actions: []AutosquashAction{AutosquashAmend},
+				Target:  "fix: a broken thing",
+			},
+		},
+		{
+			name:    "stacked",
+			message: "fixup! squash! fixup! fix: a broken thing",
+			want: &Autosquash{
+				Actions: []AutosquashAction{
+					AutosquashFixup,
+					AutosquashSquash,
+					AutosquashFixup,
+				},
+				Target: "fix: a broken thing",
+			},
+		},
+		{
+			name:    "commit hash",
+			message: "fixup! abcdef0",
+			want: &Autosquash{
+				Actions: []AutosquashAction{AutosquashFixup},
+				Target:  "abcdef0",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewRawMessage([]byte(tt.message))
+
+			got := msg.Autosquash()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
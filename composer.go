@@ -0,0 +1,318 @@
+package conventionalcommit
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// CommandRunner runs git with the given arguments, reading its standard input
+// from stdin.
+type CommandRunner func(args []string, stdin io.Reader) error
+
+// Composer interactively prompts for each part of a conventional commit
+// message, in the style of commitizen. Answers are read line by line from In,
+// and prompts are written to Out, so the flow can be driven by a terminal or a
+// script. Invalid answers are reported, and their prompt repeated.
+type Composer struct {
+	// In is where answers are read from, one per line.
+	In io.Reader
+
+	// Out is where prompts and validation errors are written to.
+	Out io.Writer
+
+	// Types are the types which can be chosen, either by name or by their
+	// number in the listing. Aliases are accepted too. If nil, the Angular
+	// preset's types are used.
+	Types *TypeRegistry
+
+	// Scopes are the scopes which can be chosen. If nil, any valid scope is
+	// accepted.
+	Scopes *ScopeRegistry
+
+	// Run runs the git command used by Commit. If nil, git is executed with
+	// its output written to os.Stdout and os.Stderr.
+	Run CommandRunner
+}
+
+// Commit creates a commit with the given message by running
+// "git commit -F -", passing the message on standard input.
+func (s *Composer) Commit(msg *RawMessage) error {
+	run := s.Run
+	if run == nil {
+		run = runCommand
+	}
+
+	args := []string{"commit", "-F", "-"}
+	if err := run(args, bytes.NewReader(msg.Bytes())); err != nil {
+		return fmt.Errorf("git commit: %w", err)
+	}
+
+	return nil
+}
+
+// runCommand is the default CommandRunner, which executes git.
+func runCommand(args []string, stdin io.Reader) error {
+	cmd := exec.Command("git", args...)
+	cmd.Stdin = stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
+// Compose prompts for the type, scope, description, body, breaking change and
+// closed issues, and returns the resulting message. Breaking changes are
+// marked with "!" in the header and described in a "BREAKING CHANGE" trailer,
+// and issues are listed in a "Closes" trailer. It returns io.ErrUnexpectedEOF
+// if In ends before all questions have been answered.
+func (s *Composer) Compose() (*RawMessage, error) {
+	in := bufio.NewReader(s.In)
+
+	typ, err := s.promptType(in)
+	if err != nil {
+		return nil, err
+	}
+
+	scope, err := s.promptScope(in, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	description, err := s.prompt(in,
+		"Short description of the change: ",
+		func(answer string) (string, error) {
+			h, err := ParseHeader(&Line{
+				Content: []byte(typ + ": " + answer),
+			})
+			if err != nil {
+				return "", err
+			}
+			if h.TrailingPeriodFix() != nil {
+				return "", errors.New(
+					"description must not end with a period",
+				)
+			}
+
+			return answer, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprint(s.Out, "Longer description (finish with an empty line):\n")
+	body := []string{}
+	for {
+		line, err := readLine(in)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		body = append(body, line)
+	}
+
+	breaking, err := s.prompt(in,
+		"Breaking change (leave empty if none): ",
+		noValidation,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	issues, err := s.prompt(in,
+		"Issues closed, for example \"#12\" (leave empty if none): ",
+		noValidation,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	header := typ
+	if scope != "" {
+		header += "(" + scope + ")"
+	}
+	if breaking != "" {
+		header += "!"
+	}
+	header += ": " + description
+
+	b := []byte(header + "\n")
+	if len(body) > 0 {
+		b = append(b, "\n"+strings.Join(body, "\n")+"\n"...)
+	}
+
+	msg := NewRawMessage(b)
+
+	edits := []*TrailerEdit{}
+	if breaking != "" {
+		edits = append(edits, &TrailerEdit{
+			Token: "BREAKING CHANGE",
+			Value: breaking,
+		})
+	}
+	if issues != "" {
+		edits = append(edits, &TrailerEdit{Token: "Closes", Value: issues})
+	}
+	if len(edits) > 0 {
+		msg = msg.EditTrailers(edits...)
+	}
+
+	return msg, nil
+}
+
+// promptType prompts for a type, listing the registered types.
+func (s *Composer) promptType(in *bufio.Reader) (string, error) {
+	registry := s.Types
+	if registry == nil {
+		registry = DefaultTypeRegistry()
+	}
+	types := registry.Types()
+
+	fmt.Fprint(s.Out, "Select the type of change:\n")
+	for i, t := range types {
+		fmt.Fprintf(s.Out, "%3d. %-10s %s\n", i+1, t.Name, t.Description)
+	}
+
+	return s.prompt(in, "Type: ", func(answer string) (string, error) {
+		if n, err := strconv.Atoi(answer); err == nil {
+			if n < 1 || n > len(types) {
+				return "", fmt.Errorf("no type numbered %d", n)
+			}
+
+			return types[n-1].Name, nil
+		}
+
+		if t, ok := registry.Get(answer); ok {
+			return t.Name, nil
+		}
+
+		suggestion, ok := registry.Suggest(answer)
+		if !ok {
+			return "", fmt.Errorf("unknown type %q", answer)
+		}
+
+		lower := strings.ToLower(answer)
+		t, _ := registry.Get(suggestion)
+		for _, alias := range t.Aliases {
+			if strings.ToLower(alias) == lower {
+				return t.Name, nil
+			}
+		}
+
+		return "", fmt.Errorf(
+			"unknown type %q, did you mean %q?", answer, suggestion,
+		)
+	})
+}
+
+// promptScope prompts for an optional scope, listing the registered scopes.
+func (s *Composer) promptScope(
+	in *bufio.Reader,
+	typ string,
+) (string, error) {
+	prompt := "Scope (leave empty if none): "
+	if s.Scopes != nil && len(s.Scopes.Names()) > 0 {
+		prompt = fmt.Sprintf(
+			"Scope, one of %s (leave empty if none): ",
+			strings.Join(s.Scopes.Names(), ", "),
+		)
+	}
+
+	return s.prompt(in, prompt, func(answer string) (string, error) {
+		if answer == "" {
+			return "", nil
+		}
+		if i := strings.IndexAny(answer, "():"); i >= 0 {
+			return "", fmt.Errorf(
+				"scope must not contain %q", answer[i:i+1],
+			)
+		}
+
+		h, err := ParseHeader(&Line{
+			Content: []byte(typ + "(" + answer + "): x"),
+		})
+		if err != nil {
+			return "", err
+		}
+
+		if s.Scopes == nil {
+			return answer, nil
+		}
+		for _, scope := range h.Scopes {
+			if _, ok := s.Scopes.Lookup(scope); ok {
+				continue
+			}
+			if suggestion, ok := s.Scopes.Suggest(scope.Name); ok {
+				return "", fmt.Errorf(
+					"unknown scope %q, did you mean %q?",
+					scope.Name, suggestion,
+				)
+			}
+
+			return "", fmt.Errorf("unknown scope %q", scope.Name)
+		}
+
+		return answer, nil
+	})
+}
+
+// prompt writes the given prompt and reads answers until one passes
+// validation, writing the validation error after each failed answer. It
+// returns the validated answer.
+func (s *Composer) prompt(
+	in *bufio.Reader,
+	prompt string,
+	validate func(answer string) (string, error),
+) (string, error) {
+	for {
+		fmt.Fprint(s.Out, prompt)
+
+		answer, err := readAnswer(in)
+		if err != nil {
+			return "", err
+		}
+
+		r, err := validate(answer)
+		if err == nil {
+			return r, nil
+		}
+
+		fmt.Fprintf(s.Out, "error: %s\n", err)
+	}
+}
+
+// noValidation accepts any answer as is.
+func noValidation(answer string) (string, error) {
+	return answer, nil
+}
+
+// readAnswer reads a single line, with surrounding whitespace removed.
+func readAnswer(in *bufio.Reader) (string, error) {
+	line, err := readLine(in)
+
+	return strings.TrimSpace(line), err
+}
+
+// readLine reads a single line, without its line break. It returns
+// io.ErrUnexpectedEOF if the reader has no more lines.
+func readLine(in *bufio.Reader) (string, error) {
+	line, err := in.ReadString('\n')
+	if err == io.EOF {
+		if line == "" {
+			return "", io.ErrUnexpectedEOF
+		}
+	} else if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(line, "\r\n"), nil
+}
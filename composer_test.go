@@ -0,0 +1,170 @@
+package conventionalcommit
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComposer_Compose(t *testing.T) {
+	tests := []struct {
+		name       string
+		scopes     *ScopeRegistry
+		input      string
+		want       string
+		wantOutput []string
+		wantErr    error
+	}{
+		{
+			name:  "header only",
+			input: "feat\n\nadd a thing\n\n\n\n",
+			want:  "feat: add a thing\n",
+		},
+		{
+			name:  "type by number",
+			input: "2\napi\na broken thing\n\n\n\n",
+			want:  "fix(api): a broken thing\n",
+		},
+		{
+			name: "everything",
+			input: "feat\napi, cli\nadd a thing\n" +
+				"More details.\n  - indented\n\n" +
+				"the old thing is gone\n#12, #34\n",
+			want: "feat(api, cli)!: add a thing\n\n" +
+				"More details.\n  - indented\n\n" +
+				"BREAKING CHANGE: the old thing is gone\n" +
+				"Closes: #12, #34\n",
+		},
+		{
+			name:  "alias",
+			input: "Feature\n\nadd a thing\n\n\n\n",
+			want:  "feat: add a thing\n",
+		},
+		{
+			name:  "invalid answers are repeated",
+			input: "feta\n99\nfeat\napi users\n\n \nadd a thing\n\n\n\n",
+			want:  "feat: add a thing\n",
+			wantOutput: []string{
+				"error: unknown type \"feta\", did you mean \"feat\"?\n",
+				"error: no type numbered 99\n",
+				"error: invalid header: invalid scope \"api users\"\n",
+				"error: invalid header: missing description\n",
+			},
+		},
+		{
+			name:  "description with trailing period",
+			input: "feat\n\nadd a thing.\nadd a thing...\n\n\n\n",
+			want:  "feat: add a thing...\n",
+			wantOutput: []string{
+				"error: description must not end with a period\n",
+			},
+		},
+		{
+			name:   "registered scopes",
+			scopes: NewScopeRegistry(&Scope{Name: "api"}, &Scope{Name: "cli"}),
+			input:  "fix\nweb\napl\napi/users\na broken thing\n\n\n\n",
+			want:   "fix(api/users): a broken thing\n",
+			wantOutput: []string{
+				"Scope, one of api, cli (leave empty if none): ",
+				"error: unknown scope \"web\"\n",
+				"error: unknown scope \"apl\", did you mean \"api\"?\n",
+			},
+		},
+		{
+			name:   "scope with header syntax",
+			scopes: NewScopeRegistry(&Scope{Name: "a"}),
+			input:  "feat\na): foo (b\na\nadd a thing\n\n\n\n",
+			want:   "feat(a): add a thing\n",
+			wantOutput: []string{
+				"error: scope must not contain \")\"\n",
+			},
+		},
+		{
+			name:    "input ends early",
+			input:   "feat\n\nadd a thing\nMore details.",
+			wantErr: io.ErrUnexpectedEOF,
+		},
+		{
+			name:  "final answer without line break",
+			input: "feat\n\nadd a thing\n\n\n#12",
+			want:  "feat: add a thing\n\nCloses: #12\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			c := &Composer{
+				In:     strings.NewReader(tt.input),
+				Out:    out,
+				Types:  DefaultTypeRegistry(),
+				Scopes: tt.scopes,
+			}
+
+			got, err := c.Compose()
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.String())
+			assert.Contains(t, out.String(), "  1. feat       A new feature\n")
+			for _, s := range tt.wantOutput {
+				assert.Contains(t, out.String(), s)
+			}
+		})
+	}
+}
+
+func TestComposer_Compose_DefaultTypes(t *testing.T) {
+	c := &Composer{
+		In:  strings.NewReader("feat\n\nadd a thing\n\n\n\n"),
+		Out: &bytes.Buffer{},
+	}
+
+	got, err := c.Compose()
+
+	require.NoError(t, err)
+	assert.Equal(t, "feat: add a thing\n", got.String())
+}
+
+func TestComposer_Commit(t *testing.T) {
+	var gotArgs []string
+	var gotStdin []byte
+	c := &Composer{
+		Run: func(args []string, stdin io.Reader) error {
+			gotArgs = args
+			b, err := ioutil.ReadAll(stdin)
+			gotStdin = b
+
+			return err
+		},
+	}
+	msg := NewRawMessage([]byte("feat: add a thing\n\nCloses: #12\n"))
+
+	err := c.Commit(msg)
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"commit", "-F", "-"}, gotArgs)
+	assert.Equal(t, msg.Bytes(), gotStdin)
+
+	c.Run = func([]string, io.Reader) error {
+		return errFailed
+	}
+
+	err = c.Commit(msg)
+
+	assert.ErrorIs(t, err, errFailed)
+	assert.EqualError(t, err, "git commit: failed")
+}
+
+var errFailed = errors.New("failed")
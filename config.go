@@ -0,0 +1,229 @@
+package conventionalcommit
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// ConfigFileName is the name of the configuration file looked up by
+// LoadConfig.
+const ConfigFileName = ".conventionalcommit.json"
+
+// ErrInvalidConfig is returned when a configuration file cannot be parsed.
+var ErrInvalidConfig = errors.New("invalid config")
+
+// Config is the configuration of a repository, defining its commit types and
+// scopes.
+type Config struct {
+	// Preset is the set of types which Types are added to. Either "angular",
+	// the default, or "none".
+	Preset string `json:"preset"`
+
+	// Types are added to the preset's types. A type with the same name as a
+	// preset type overrides the fields which it sets.
+	Types []*ConfigType `json:"types"`
+
+	// Scopes are the explicitly declared scopes.
+	Scopes []*ConfigScope `json:"scopes"`
+
+	// ScopeGlobs are slash-separated glob patterns relative to the directory
+	// of the configuration file, with a scope added for each matching
+	// directory. See ScopeRegistry.AddGlob.
+	ScopeGlobs []string `json:"scopeGlobs"`
+
+	// GoModuleScopes adds a scope for each Go module within the directory of
+	// the configuration file. See ScopeRegistry.AddGoModules.
+	GoModuleScopes bool `json:"goModuleScopes"`
+
+	// Dir is the directory of the configuration file, or empty if the config
+	// was not read from a file.
+	Dir string `json:"-"`
+}
+
+// ConfigType is the configuration of a single commit type. See Type. Fields
+// which are not set are taken from the preset type with the same name, if any.
+type ConfigType struct {
+	Name        string   `json:"name"`
+	Aliases     []string `json:"aliases"`
+	Description string   `json:"description"`
+	Title       string   `json:"title"`
+	Hidden      *bool    `json:"hidden"`
+	Bump        *Bump    `json:"bump"`
+}
+
+// ConfigScope is the configuration of a single commit scope. See Scope.
+type ConfigScope struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Paths       []string `json:"paths"`
+}
+
+// FindConfig returns the path of the configuration file which applies to the
+// given directory. It looks for ConfigFileName in dir and each of its parents,
+// stopping at the repository root, which is the first directory containing
+// ".git". It returns an empty path if there is no configuration file.
+func FindConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		p := filepath.Join(dir, ConfigFileName)
+		if _, err := os.Stat(p); err == nil {
+			return p, nil
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// LoadConfig finds and reads the configuration file which applies to the given
+// directory, as found by FindConfig. If there is none, it returns an empty
+// Config, which uses the default types and has no scopes.
+func LoadConfig(dir string) (*Config, error) {
+	p, err := FindConfig(dir)
+	if err != nil {
+		return nil, err
+	}
+	if p == "" {
+		return &Config{}, nil
+	}
+
+	return ReadConfigFile(p)
+}
+
+// ReadConfigFile reads the configuration file at the given path.
+func ReadConfigFile(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := ParseConfig(b)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	c.Dir = filepath.Dir(path)
+
+	return c, nil
+}
+
+// ParseConfig parses the given JSON configuration. Unknown fields are
+// rejected, to catch typos.
+func ParseConfig(b []byte) (*Config, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+
+	c := &Config{}
+	if err := dec.Decode(c); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	switch c.Preset {
+	case "", "angular", "none":
+	default:
+		return nil, fmt.Errorf(
+			"%w: unknown preset %q", ErrInvalidConfig, c.Preset,
+		)
+	}
+
+	for _, t := range c.Types {
+		if t.Name == "" {
+			return nil, fmt.Errorf("%w: type without name", ErrInvalidConfig)
+		}
+	}
+	for _, scope := range c.Scopes {
+		if scope.Name == "" {
+			return nil, fmt.Errorf("%w: scope without name", ErrInvalidConfig)
+		}
+	}
+
+	return c, nil
+}
+
+// TypeRegistry returns a TypeRegistry containing the preset's types and the
+// configured types.
+func (s *Config) TypeRegistry() *TypeRegistry {
+	r := NewTypeRegistry()
+	if s.Preset != "none" {
+		r = DefaultTypeRegistry()
+	}
+
+	for _, t := range s.Types {
+		typ := &Type{}
+		if existing, ok := r.Get(t.Name); ok {
+			*typ = *existing
+		}
+
+		typ.Name = t.Name
+		if t.Aliases != nil {
+			typ.Aliases = t.Aliases
+		}
+		if t.Description != "" {
+			typ.Description = t.Description
+		}
+		if t.Title != "" {
+			typ.Title = t.Title
+		}
+		if t.Hidden != nil {
+			typ.Hidden = *t.Hidden
+		}
+		if t.Bump != nil {
+			typ.Bump = *t.Bump
+		}
+
+		r.Add(typ)
+	}
+
+	return r
+}
+
+// ScopeRegistry returns a ScopeRegistry containing the configured scopes,
+// followed by the scopes derived from ScopeGlobs and GoModuleScopes.
+func (s *Config) ScopeRegistry() (*ScopeRegistry, error) {
+	r := NewScopeRegistry()
+
+	for _, scope := range s.Scopes {
+		r.Add(&Scope{
+			Name:        scope.Name,
+			Description: scope.Description,
+			Paths:       append([]string{}, scope.Paths...),
+		})
+	}
+
+	dir := s.Dir
+	if dir == "" {
+		dir = "."
+	}
+
+	for _, pattern := range s.ScopeGlobs {
+		if err := r.AddGlob(dir, pattern); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.GoModuleScopes {
+		if err := r.AddGoModules(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	return r, nil
+}
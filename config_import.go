@@ -0,0 +1,429 @@
+package conventionalcommit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// commitlintPresets are the commitlint shareable configs whose type-enum
+// matches the Angular preset.
+var commitlintPresets = map[string]bool{
+	"@commitlint/config-conventional": true,
+	"@commitlint/config-angular":      true,
+}
+
+// releasePlugins are the semantic-release plugins whose options describe
+// commit types.
+var releasePlugins = map[string]bool{
+	"@semantic-release/commit-analyzer":         true,
+	"@semantic-release/release-notes-generator": true,
+}
+
+// commitlintRule is a single commitlint rule, written as an array of its
+// level, when it applies ("always" or "never"), and its value.
+type commitlintRule struct {
+	Level  int
+	Always bool
+	Value  json.RawMessage
+}
+
+// UnmarshalJSON parses a rule array like [2, "always", ["feat", "fix"]].
+func (s *commitlintRule) UnmarshalJSON(b []byte) error {
+	parts := []json.RawMessage{}
+	if err := json.Unmarshal(b, &parts); err != nil {
+		return err
+	}
+	if len(parts) == 0 || len(parts) > 3 {
+		return fmt.Errorf("rule %s must have one to three elements", b)
+	}
+
+	if err := json.Unmarshal(parts[0], &s.Level); err != nil {
+		return fmt.Errorf("rule level: %w", err)
+	}
+
+	s.Always = true
+	if len(parts) > 1 {
+		var applicable string
+		if err := json.Unmarshal(parts[1], &applicable); err != nil {
+			return fmt.Errorf("rule applicability: %w", err)
+		}
+
+		switch applicable {
+		case "always":
+		case "never":
+			s.Always = false
+		default:
+			return fmt.Errorf("unknown rule applicability %q", applicable)
+		}
+	}
+
+	if len(parts) > 2 {
+		s.Value = parts[2]
+	}
+
+	return nil
+}
+
+// releaseOptions are the options of the semantic-release plugins which
+// describe commit types.
+type releaseOptions struct {
+	Preset       string          `json:"preset"`
+	ReleaseRules json.RawMessage `json:"releaseRules"`
+	PresetConfig struct {
+		Types []struct {
+			Type    string `json:"type"`
+			Section string `json:"section"`
+			Hidden  *bool  `json:"hidden"`
+		} `json:"types"`
+	} `json:"presetConfig"`
+}
+
+// ImportCommitlint converts the given commitlint JSON configuration, as found
+// in ".commitlintrc.json" or "commitlint.config.json", into the config.
+//
+// The "type-enum" rule sets the types, keeping the settings of known types,
+// and "scope-enum" adds scopes. Extending "@commitlint/config-conventional"
+// or "@commitlint/config-angular" matches the Angular preset. Disabled rules
+// are ignored.
+//
+// Rules which are not about types and scopes, scopes which are disallowed
+// with "never", and other shareable configs cannot be converted. A
+// description of each of them is returned.
+func (s *Config) ImportCommitlint(b []byte) ([]string, error) {
+	c := struct {
+		Extends json.RawMessage            `json:"extends"`
+		Rules   map[string]*commitlintRule `json:"rules"`
+	}{}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	unsupported := []string{}
+
+	extends, err := jsonStrings(c.Extends)
+	if err != nil {
+		return nil, fmt.Errorf("%w: extends: %v", ErrInvalidConfig, err)
+	}
+	for _, e := range extends {
+		if !commitlintPresets[e] {
+			unsupported = append(unsupported, fmt.Sprintf("extends %q", e))
+		}
+	}
+
+	names := make([]string, 0, len(c.Rules))
+	for name := range c.Rules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		rule := c.Rules[name]
+		if rule == nil || rule.Level == 0 {
+			continue
+		}
+
+		if name != "type-enum" && name != "scope-enum" {
+			unsupported = append(unsupported, fmt.Sprintf("rule %q", name))
+
+			continue
+		}
+
+		values, err := jsonStrings(rule.Value)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"%w: %s: %v", ErrInvalidConfig, name, err,
+			)
+		}
+
+		switch {
+		case name == "type-enum":
+			s.importTypeEnum(values, rule.Always)
+		case rule.Always:
+			s.importScopeEnum(values)
+		default:
+			unsupported = append(unsupported,
+				fmt.Sprintf("rule %q with \"never\"", name),
+			)
+		}
+	}
+
+	return unsupported, nil
+}
+
+// ImportSemanticRelease converts the given semantic-release JSON
+// configuration, as found in ".releaserc" or ".releaserc.json", into the
+// config.
+//
+// Release rules of the commit analyzer which only match a type set the bump
+// level of that type, with a release of false meaning no bump. The types of a
+// "conventionalcommits" preset config set the title and visibility of types.
+// Options may be given to the plugins, or at the top level.
+//
+// Release rules matching anything else, like scopes, release rules loaded from
+// a module, and presets other than "angular" and "conventionalcommits" cannot
+// be converted. A description of each of them is returned.
+func (s *Config) ImportSemanticRelease(b []byte) ([]string, error) {
+	c := struct {
+		releaseOptions
+		Plugins []json.RawMessage `json:"plugins"`
+	}{}
+	if err := json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
+
+	options := []*releaseOptions{&c.releaseOptions}
+	for _, p := range c.Plugins {
+		name, opts, err := parseReleasePlugin(p)
+		if err != nil {
+			return nil, fmt.Errorf("%w: plugins: %v", ErrInvalidConfig, err)
+		}
+		if releasePlugins[name] && opts != nil {
+			options = append(options, opts)
+		}
+	}
+
+	unsupported := []string{}
+	for _, opts := range options {
+		u, err := s.importReleaseOptions(opts)
+		if err != nil {
+			return nil, err
+		}
+		unsupported = append(unsupported, u...)
+	}
+
+	return unsupported, nil
+}
+
+// parseReleasePlugin parses a semantic-release plugin, which is either its
+// name, or an array of its name and options.
+func parseReleasePlugin(b json.RawMessage) (string, *releaseOptions, error) {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		return name, nil, nil
+	}
+
+	parts := []json.RawMessage{}
+	if err := json.Unmarshal(b, &parts); err != nil {
+		return "", nil, err
+	}
+	if len(parts) == 0 {
+		return "", nil, fmt.Errorf("plugin %s has no name", b)
+	}
+
+	if err := json.Unmarshal(parts[0], &name); err != nil {
+		return "", nil, err
+	}
+	if len(parts) == 1 {
+		return name, nil, nil
+	}
+
+	opts := &releaseOptions{}
+	if err := json.Unmarshal(parts[1], opts); err != nil {
+		return "", nil, fmt.Errorf("%s: %w", name, err)
+	}
+
+	return name, opts, nil
+}
+
+// importReleaseOptions imports the options of a single semantic-release
+// plugin, and returns a description of each option which cannot be converted.
+func (s *Config) importReleaseOptions(opts *releaseOptions) ([]string, error) {
+	unsupported := []string{}
+
+	switch opts.Preset {
+	case "", "angular", "conventionalcommits":
+	default:
+		unsupported = append(unsupported,
+			fmt.Sprintf("preset %q", opts.Preset),
+		)
+	}
+
+	for _, t := range opts.PresetConfig.Types {
+		ct := s.configType(t.Type)
+		if ct == nil {
+			unsupported = append(unsupported,
+				fmt.Sprintf("preset config for unknown type %q", t.Type),
+			)
+
+			continue
+		}
+		if t.Section != "" {
+			ct.Title = t.Section
+		}
+		if t.Hidden != nil {
+			hidden := *t.Hidden
+			ct.Hidden = &hidden
+		}
+	}
+
+	if len(opts.ReleaseRules) == 0 {
+		return unsupported, nil
+	}
+
+	var module string
+	if err := json.Unmarshal(opts.ReleaseRules, &module); err == nil {
+		return append(unsupported,
+			fmt.Sprintf("release rules module %q", module),
+		), nil
+	}
+
+	rules := []map[string]json.RawMessage{}
+	if err := json.Unmarshal(opts.ReleaseRules, &rules); err != nil {
+		return nil, fmt.Errorf("%w: releaseRules: %v", ErrInvalidConfig, err)
+	}
+
+	for _, rule := range rules {
+		ok, err := s.importReleaseRule(rule)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			b, _ := json.Marshal(rule)
+			unsupported = append(unsupported,
+				fmt.Sprintf("release rule %s", b),
+			)
+		}
+	}
+
+	return unsupported, nil
+}
+
+// importReleaseRule sets the bump level of the type matched by the given
+// release rule. It returns false if the rule matches anything other than a
+// single type, or a type which is not allowed.
+func (s *Config) importReleaseRule(
+	rule map[string]json.RawMessage,
+) (bool, error) {
+	if len(rule) != 2 || rule["type"] == nil || rule["release"] == nil {
+		return false, nil
+	}
+
+	var name string
+	if err := json.Unmarshal(rule["type"], &name); err != nil {
+		return false, fmt.Errorf("%w: release rule type: %v",
+			ErrInvalidConfig, err,
+		)
+	}
+	if strings.ContainsAny(name, "*?[{/") {
+		return false, nil
+	}
+
+	bump := BumpNone
+	if !bytes.Equal(rule["release"], []byte("false")) {
+		if err := json.Unmarshal(rule["release"], &bump); err != nil {
+			return false, fmt.Errorf("%w: release rule: %v",
+				ErrInvalidConfig, err,
+			)
+		}
+	}
+
+	ct := s.configType(name)
+	if ct == nil {
+		return false, nil
+	}
+	ct.Bump = &bump
+
+	return true, nil
+}
+
+// importTypeEnum sets the types to the given names, or to all other types if
+// always is false. The settings of types which already exist are kept.
+func (s *Config) importTypeEnum(names []string, always bool) {
+	existing := s.TypeRegistry()
+	listed := map[string]bool{}
+	for _, name := range names {
+		listed[strings.ToLower(name)] = true
+	}
+
+	if !always {
+		names = []string{}
+		for _, name := range existing.Names() {
+			if !listed[strings.ToLower(name)] {
+				names = append(names, name)
+			}
+		}
+	}
+
+	s.Preset = "none"
+	s.Types = []*ConfigType{}
+	for _, name := range names {
+		t, ok := existing.Get(name)
+		if !ok {
+			s.Types = append(s.Types, &ConfigType{Name: name})
+
+			continue
+		}
+
+		hidden, bump := t.Hidden, t.Bump
+		s.Types = append(s.Types, &ConfigType{
+			Name:        name,
+			Aliases:     t.Aliases,
+			Description: t.Description,
+			Title:       t.Title,
+			Hidden:      &hidden,
+			Bump:        &bump,
+		})
+	}
+}
+
+// importScopeEnum adds a scope for each of the given names which is not
+// configured yet.
+func (s *Config) importScopeEnum(names []string) {
+	for _, name := range names {
+		found := false
+		for _, scope := range s.Scopes {
+			if strings.EqualFold(scope.Name, name) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			s.Scopes = append(s.Scopes, &ConfigScope{Name: name})
+		}
+	}
+}
+
+// configType returns the configured type with the given name. If there is
+// none, a type is added, unless the types are restricted to the configured
+// ones by the "none" preset, in which case nil is returned.
+func (s *Config) configType(name string) *ConfigType {
+	for _, t := range s.Types {
+		if strings.EqualFold(t.Name, name) {
+			return t
+		}
+	}
+
+	if s.Preset == "none" {
+		return nil
+	}
+
+	t := &ConfigType{Name: name}
+	s.Types = append(s.Types, t)
+
+	return t
+}
+
+// jsonStrings parses a JSON string or array of strings. A missing value is an
+// empty list.
+func jsonStrings(b json.RawMessage) ([]string, error) {
+	if len(b) == 0 || bytes.Equal(b, []byte("null")) {
+		return []string{}, nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		return []string{s}, nil
+	}
+
+	r := []string{}
+	if err := json.Unmarshal(b, &r); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -0,0 +1,287 @@
+package conventionalcommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_ImportCommitlint(t *testing.T) {
+	fix := AngularTypes()[1]
+	none := BumpNone
+	minor := BumpMinor
+	patch := BumpPatch
+	hidden := true
+	visible := false
+	tests := []struct {
+		name            string
+		config          string
+		want            *Config
+		wantUnsupported []string
+		wantErr         string
+	}{
+		{
+			name:            "conventional preset",
+			config:          `{"extends": ["@commitlint/config-conventional"]}`,
+			want:            &Config{},
+			wantUnsupported: []string{},
+		},
+		{
+			name: "type and scope enums",
+			config: `{
+				"extends": "@commitlint/config-conventional",
+				"rules": {
+					"type-enum": [2, "always", ["feat", "fix", "deps"]],
+					"scope-enum": [2, "always", ["api", "web"]],
+					"header-max-length": [2, "always", 72],
+					"subject-case": [0]
+				}
+			}`,
+			want: &Config{
+				Preset: "none",
+				Types: []*ConfigType{
+					{
+						Name:        "feat",
+						Aliases:     []string{"feature", "features"},
+						Description: "A new feature",
+						Title:       "Features",
+						Hidden:      &visible,
+						Bump:        &minor,
+					},
+					{
+						Name:        "fix",
+						Aliases:     fix.Aliases,
+						Description: "A bug fix",
+						Title:       "Bug Fixes",
+						Hidden:      &visible,
+						Bump:        &patch,
+					},
+					{Name: "deps"},
+				},
+				Scopes: []*ConfigScope{{Name: "api"}, {Name: "web"}},
+			},
+			wantUnsupported: []string{`rule "header-max-length"`},
+		},
+		{
+			name: "disallowed types and scopes",
+			config: `{
+				"extends": ["@commitlint/config-angular", "./local.js"],
+				"rules": {
+					"type-enum": [1, "never", [
+						"feat", "fix", "perf", "revert", "style", "refactor",
+						"test", "build", "ci", "chore"
+					]],
+					"scope-enum": [2, "never", ["deps"]]
+				}
+			}`,
+			want: &Config{
+				Preset: "none",
+				Types: []*ConfigType{
+					{
+						Name:        "docs",
+						Aliases:     []string{"doc", "documentation"},
+						Description: "Documentation only changes",
+						Title:       "Documentation",
+						Hidden:      &hidden,
+						Bump:        &none,
+					},
+				},
+			},
+			wantUnsupported: []string{
+				`extends "./local.js"`,
+				`rule "scope-enum" with "never"`,
+			},
+		},
+		{
+			name:    "invalid JSON",
+			config:  `{"rules": `,
+			wantErr: "invalid config: unexpected end of JSON input",
+		},
+		{
+			name:    "invalid rule",
+			config:  `{"rules": {"type-enum": [2, "sometimes", []]}}`,
+			wantErr: `invalid config: unknown rule applicability "sometimes"`,
+		},
+		{
+			name:   "invalid enum",
+			config: `{"rules": {"type-enum": [2, "always", [1]]}}`,
+			wantErr: "invalid config: type-enum: json: cannot unmarshal " +
+				"number into ",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Config{}
+
+			unsupported, err := c.ImportCommitlint([]byte(tt.config))
+
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrInvalidConfig)
+				assert.Contains(t, err.Error(), tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, c)
+			assert.Equal(t, tt.wantUnsupported, unsupported)
+		})
+	}
+}
+
+func TestConfig_ImportSemanticRelease(t *testing.T) {
+	none := BumpNone
+	minor := BumpMinor
+	patch := BumpPatch
+	visible := false
+	tests := []struct {
+		name            string
+		config          *Config
+		release         string
+		want            *Config
+		wantUnsupported []string
+		wantErr         string
+	}{
+		{
+			name:   "release rules and preset config",
+			config: &Config{},
+			release: `{
+				"branches": ["main"],
+				"plugins": [
+					["@semantic-release/commit-analyzer", {
+						"preset": "conventionalcommits",
+						"releaseRules": [
+							{"type": "docs", "release": "patch"},
+							{"type": "deps", "release": "minor"},
+							{"type": "refactor", "release": false},
+							{
+								"type": "docs",
+								"scope": "README",
+								"release": "patch"
+							}
+						]
+					}],
+					["@semantic-release/release-notes-generator", {
+						"presetConfig": {
+							"types": [
+								{
+									"type": "docs",
+									"section": "Docs",
+									"hidden": false
+								}
+							]
+						}
+					}],
+					"@semantic-release/github"
+				]
+			}`,
+			want: &Config{
+				Types: []*ConfigType{
+					{
+						Name:   "docs",
+						Title:  "Docs",
+						Hidden: &visible,
+						Bump:   &patch,
+					},
+					{Name: "deps", Bump: &minor},
+					{Name: "refactor", Bump: &none},
+				},
+			},
+			wantUnsupported: []string{
+				`release rule ` +
+					`{"release":"patch","scope":"README","type":"docs"}`,
+			},
+		},
+		{
+			name: "types restricted by commitlint",
+			config: &Config{
+				Preset: "none",
+				Types:  []*ConfigType{{Name: "feat"}, {Name: "fix"}},
+			},
+			release: `{
+				"preset": "angular",
+				"releaseRules": [
+					{"type": "fix", "release": "minor"},
+					{"type": "chore", "release": "patch"}
+				]
+			}`,
+			want: &Config{
+				Preset: "none",
+				Types: []*ConfigType{
+					{Name: "feat"},
+					{Name: "fix", Bump: &minor},
+				},
+			},
+			wantUnsupported: []string{
+				`release rule {"release":"patch","type":"chore"}`,
+			},
+		},
+		{
+			name:   "unsupported options",
+			config: &Config{},
+			release: `{
+				"plugins": [
+					["@semantic-release/commit-analyzer", {
+						"preset": "eslint",
+						"releaseRules": "./release-rules.js"
+					}]
+				]
+			}`,
+			want: &Config{},
+			wantUnsupported: []string{
+				`preset "eslint"`,
+				`release rules module "./release-rules.js"`,
+			},
+		},
+		{
+			name:    "invalid release",
+			config:  &Config{},
+			release: `{"releaseRules": [{"type": "feat", "release": "huge"}]}`,
+			wantErr: `invalid config: release rule: ` +
+				`unknown bump level "huge"`,
+		},
+		{
+			name:    "invalid plugin",
+			config:  &Config{},
+			release: `{"plugins": [[]]}`,
+			wantErr: "invalid config: plugins: plugin [] has no name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unsupported, err := tt.config.ImportSemanticRelease(
+				[]byte(tt.release),
+			)
+
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrInvalidConfig)
+				assert.EqualError(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, tt.config)
+			assert.Equal(t, tt.wantUnsupported, unsupported)
+		})
+	}
+}
+
+func TestConfig_Import(t *testing.T) {
+	c := &Config{}
+
+	_, err := c.ImportCommitlint([]byte(`{
+		"rules": {"type-enum": [2, "always", ["feat", "fix", "deps"]]}
+	}`))
+	require.NoError(t, err)
+	_, err = c.ImportSemanticRelease([]byte(`{
+		"releaseRules": [{"type": "deps", "release": "patch"}]
+	}`))
+	require.NoError(t, err)
+
+	types := c.TypeRegistry()
+	assert.Equal(t, []string{"feat", "fix", "deps"}, types.Names())
+	assert.Equal(t, BumpMinor, types.Bump("feat", false))
+	assert.Equal(t, BumpPatch, types.Bump("deps", false))
+}
@@ -0,0 +1,274 @@
+package conventionalcommit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfig(t *testing.T) {
+	minor := BumpMinor
+	hidden := true
+	tests := []struct {
+		name    string
+		config  string
+		want    *Config
+		wantErr string
+	}{
+		{
+			name:   "empty",
+			config: `{}`,
+			want:   &Config{},
+		},
+		{
+			name: "types and scopes",
+			config: `{
+				"preset": "none",
+				"types": [
+					{"name": "feat", "title": "Features", "bump": "minor"},
+					{"name": "deps", "aliases": ["dep"], "hidden": true}
+				],
+				"scopes": [{"name": "api", "paths": ["services/api"]}],
+				"scopeGlobs": ["packages/*"],
+				"goModuleScopes": true
+			}`,
+			want: &Config{
+				Preset: "none",
+				Types: []*ConfigType{
+					{Name: "feat", Title: "Features", Bump: &minor},
+					{Name: "deps", Aliases: []string{"dep"}, Hidden: &hidden},
+				},
+				Scopes: []*ConfigScope{
+					{Name: "api", Paths: []string{"services/api"}},
+				},
+				ScopeGlobs:     []string{"packages/*"},
+				GoModuleScopes: true,
+			},
+		},
+		{
+			name:    "invalid JSON",
+			config:  `{"types": [`,
+			wantErr: "invalid config: unexpected EOF",
+		},
+		{
+			name:   "unknown field",
+			config: `{"type": []}`,
+			wantErr: `invalid config: ` +
+				`json: unknown field "type"`,
+		},
+		{
+			name:    "unknown preset",
+			config:  `{"preset": "eslint"}`,
+			wantErr: `invalid config: unknown preset "eslint"`,
+		},
+		{
+			name:    "unknown bump",
+			config:  `{"types": [{"name": "feat", "bump": "huge"}]}`,
+			wantErr: `invalid config: unknown bump level "huge"`,
+		},
+		{
+			name:    "type without name",
+			config:  `{"types": [{"title": "Features"}]}`,
+			wantErr: "invalid config: type without name",
+		},
+		{
+			name:    "scope without name",
+			config:  `{"scopes": [{"paths": ["api"]}]}`,
+			wantErr: "invalid config: scope without name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseConfig([]byte(tt.config))
+
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrInvalidConfig)
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFindConfig(t *testing.T) {
+	root := createTree(t,
+		"repo/.git/",
+		"repo/"+ConfigFileName,
+		"repo/services/api/",
+		"repo/services/web/"+ConfigFileName,
+		"other/repo/.git/",
+		"other/repo/src/",
+		"other/"+ConfigFileName,
+	)
+	tests := []struct {
+		name string
+		dir  string
+		want string
+	}{
+		{
+			name: "in directory",
+			dir:  "repo",
+			want: "repo/" + ConfigFileName,
+		},
+		{
+			name: "in parent",
+			dir:  "repo/services/api",
+			want: "repo/" + ConfigFileName,
+		},
+		{
+			name: "nearest",
+			dir:  "repo/services/web",
+			want: "repo/services/web/" + ConfigFileName,
+		},
+		{
+			name: "stops at repository root",
+			dir:  "other/repo/src",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FindConfig(filepath.Join(root, tt.dir))
+			require.NoError(t, err)
+
+			want := tt.want
+			if want != "" {
+				want = filepath.Join(root, filepath.FromSlash(want))
+			}
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	root := createTree(t, ".git/", "services/api/", "services/web/")
+	dir := filepath.Join(root, "services", "api")
+
+	got, err := LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, &Config{}, got)
+
+	p := filepath.Join(root, ConfigFileName)
+	require.NoError(t, ioutil.WriteFile(p, []byte(`{
+		"types": [{"name": "deps", "bump": "patch"}],
+		"scopeGlobs": ["services/*"]
+	}`), 0o600))
+
+	got, err = LoadConfig(dir)
+	require.NoError(t, err)
+	assert.Equal(t, root, got.Dir)
+
+	types := got.TypeRegistry()
+	assert.Equal(t, BumpPatch, types.Bump("deps", false))
+	assert.Equal(t, BumpMinor, types.Bump("feat", false))
+
+	scopes, err := got.ScopeRegistry()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"api", "web"}, scopes.Names())
+
+	require.NoError(t, ioutil.WriteFile(p, []byte(`{"preset": 1}`), 0o600))
+
+	_, err = LoadConfig(dir)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+	assert.Contains(t, err.Error(), p+": invalid config: ")
+
+	require.NoError(t, os.Remove(p))
+	require.NoError(t, os.Mkdir(p, 0o755))
+
+	_, err = LoadConfig(dir)
+	assert.Error(t, err)
+}
+
+func TestConfig_TypeRegistry(t *testing.T) {
+	patch := BumpPatch
+	c := &Config{
+		Types: []*ConfigType{
+			{Name: "feat", Title: "New Stuff"},
+			{Name: "security", Title: "Security", Bump: &patch},
+		},
+	}
+
+	got := c.TypeRegistry()
+
+	want := append(DefaultTypeRegistry().Names(), "security")
+	assert.Equal(t, want, got.Names())
+	feat, _ := got.Get("feat")
+	assert.Equal(t, "New Stuff", feat.Title)
+	assert.Equal(t, BumpMinor, feat.Bump)
+	security, _ := got.Get("security")
+	assert.Equal(t, BumpPatch, security.Bump)
+
+	c.Preset = "none"
+	assert.Equal(t, []string{"feat", "security"}, c.TypeRegistry().Names())
+}
+
+func TestConfig_TypeRegistry_OverridePresetType(t *testing.T) {
+	c, err := ParseConfig([]byte(`{
+		"types": [
+			{"name": "feat", "aliases": ["feature2"]},
+			{"name": "docs", "hidden": false, "bump": "patch"}
+		]
+	}`))
+	require.NoError(t, err)
+
+	got := c.TypeRegistry()
+
+	feat, _ := got.Get("feat")
+	assert.Equal(t, &Type{
+		Name:        "feat",
+		Aliases:     []string{"feature2"},
+		Description: "A new feature",
+		Title:       "Features",
+		Bump:        BumpMinor,
+	}, feat)
+	assert.Equal(t, BumpMinor, got.Bump("feat", false))
+
+	docs, _ := got.Get("docs")
+	assert.Equal(t, &Type{
+		Name:        "docs",
+		Aliases:     []string{"doc", "documentation"},
+		Description: "Documentation only changes",
+		Title:       "Documentation",
+		Bump:        BumpPatch,
+	}, docs)
+
+	angular, _ := DefaultTypeRegistry().Get("feat")
+	assert.Equal(t, []string{"feature", "features"}, angular.Aliases)
+}
+
+func TestConfig_ScopeRegistry(t *testing.T) {
+	root := createTree(t,
+		"packages/api/",
+		"packages/web/",
+		"tools/lint/go.mod",
+	)
+	c := &Config{
+		Scopes: []*ConfigScope{
+			{Name: "api", Description: "The API", Paths: []string{"api"}},
+		},
+		ScopeGlobs:     []string{"packages/*"},
+		GoModuleScopes: true,
+		Dir:            root,
+	}
+
+	got, err := c.ScopeRegistry()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"api", "web", "lint"}, got.Names())
+	api, _ := got.Get("api")
+	assert.Equal(t, []string{"api", "packages/api"}, api.Paths)
+	assert.Equal(t, []string{"api"}, c.Scopes[0].Paths)
+
+	c.ScopeGlobs = []string{"[", "packages/*"}
+	_, err = c.ScopeRegistry()
+	assert.Error(t, err)
+}
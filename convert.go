@@ -0,0 +1,489 @@
+package conventionalcommit
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ConvertVerb maps the leading verb of a free-form header to a commit type.
+type ConvertVerb struct {
+	// Words are the lowercase forms of the verb, for example "fix", "fixed",
+	// and "fixes".
+	Words []string
+
+	// Type is the commit type used for headers starting with the verb.
+	Type string
+
+	// Imperative replaces the verb in the converted description. If empty,
+	// the verb is removed from the description, turning "Fixed login bug"
+	// into "fix: login bug".
+	Imperative string
+
+	// Confidence is how likely the verb is to indicate Type, from 0 to 1.
+	Confidence float64
+}
+
+// ConvertTopic maps a word anywhere in a free-form header to a commit type.
+type ConvertTopic struct {
+	// Words are the lowercase forms of the topic, for example "readme".
+	Words []string
+
+	// Type is the commit type used for headers mentioning the topic.
+	Type string
+
+	// Confidence is how likely the topic is to indicate Type, from 0 to 1.
+	// A topic is only used when its confidence is higher than the confidence
+	// of the leading verb.
+	Confidence float64
+}
+
+// Converter turns free-form legacy commit messages into conventional commit
+// messages, based on keyword tables and optional scope inference.
+type Converter struct {
+	// Verbs are matched against the first word of the header.
+	Verbs []*ConvertVerb
+
+	// Topics are matched against every word of the header.
+	Topics []*ConvertTopic
+
+	// Fallback is the type used when no verb or topic matches. Converted
+	// messages using it have zero confidence.
+	Fallback string
+
+	// Types are the types which count as already conventional. If nil, any
+	// header which parses as a conventional commit header does.
+	Types *TypeRegistry
+
+	// Scopes are used to infer the scope of a converted header. A trailing
+	// "to", "in", "for", or "of" phrase naming a registered scope is moved
+	// into the scope, and otherwise the scope is inferred from the changed
+	// paths given to Convert. If nil, converted headers have no scope.
+	Scopes *ScopeRegistry
+}
+
+// Conversion is the result of converting a single commit message.
+type Conversion struct {
+	// Type is the commit type of the converted header.
+	Type string
+
+	// Scopes are the scopes of the converted header, which may be empty.
+	Scopes []string
+
+	// Description is the description of the converted header.
+	Description string
+
+	// Confidence is how likely the conversion is to be correct, from 0 to 1.
+	// Messages which already are conventional have a confidence of 1.
+	Confidence float64
+
+	// Message is the converted message. Everything after the header is kept
+	// as is.
+	Message *RawMessage
+}
+
+// scopePrepositions introduce a trailing phrase which may name a scope, as in
+// "Add caching to API".
+var scopePrepositions = []string{"to", "in", "for", "of"}
+
+// NewConverter returns a Converter using the default keyword tables and the
+// Angular preset's types.
+func NewConverter() *Converter {
+	return &Converter{
+		Verbs:    DefaultConvertVerbs(),
+		Topics:   DefaultConvertTopics(),
+		Fallback: "chore",
+		Types:    DefaultTypeRegistry(),
+	}
+}
+
+// DefaultConvertVerbs returns common verbs which start free-form headers,
+// mapped to the types of the Angular convention.
+func DefaultConvertVerbs() []*ConvertVerb {
+	return []*ConvertVerb{
+		{
+			Words:      []string{"fix", "fixed", "fixes", "fixing"},
+			Type:       "fix",
+			Confidence: 0.8,
+		},
+		{
+			Words:      []string{"resolve", "resolved", "resolves"},
+			Type:       "fix",
+			Imperative: "resolve",
+			Confidence: 0.6,
+		},
+		{
+			Words:      []string{"add", "added", "adds", "adding"},
+			Type:       "feat",
+			Imperative: "add",
+			Confidence: 0.7,
+		},
+		{
+			Words:      []string{"implement", "implemented", "implements"},
+			Type:       "feat",
+			Imperative: "implement",
+			Confidence: 0.7,
+		},
+		{
+			Words:      []string{"introduce", "introduced", "introduces"},
+			Type:       "feat",
+			Imperative: "introduce",
+			Confidence: 0.7,
+		},
+		{
+			Words:      []string{"support", "supported", "supports"},
+			Type:       "feat",
+			Imperative: "support",
+			Confidence: 0.6,
+		},
+		{
+			Words: []string{
+				"optimize", "optimized", "optimise", "optimised",
+			},
+			Type:       "perf",
+			Imperative: "optimize",
+			Confidence: 0.7,
+		},
+		{
+			Words:      []string{"revert", "reverted", "reverts"},
+			Type:       "revert",
+			Imperative: "revert",
+			Confidence: 0.8,
+		},
+		{
+			Words:      []string{"refactor", "refactored", "refactors"},
+			Type:       "refactor",
+			Imperative: "refactor",
+			Confidence: 0.8,
+		},
+		{
+			Words:      []string{"cleanup", "clean", "cleaned", "tidy"},
+			Type:       "refactor",
+			Imperative: "clean up",
+			Confidence: 0.6,
+		},
+		{
+			Words:      []string{"rename", "renamed", "renames"},
+			Type:       "refactor",
+			Imperative: "rename",
+			Confidence: 0.6,
+		},
+		{
+			Words:      []string{"bump", "bumped", "upgrade", "upgraded"},
+			Type:       "build",
+			Imperative: "bump",
+			Confidence: 0.6,
+		},
+		{
+			Words:      []string{"format", "formatted", "reformat"},
+			Type:       "style",
+			Imperative: "format",
+			Confidence: 0.6,
+		},
+		{
+			Words:      []string{"update", "updated", "updates"},
+			Type:       "chore",
+			Imperative: "update",
+			Confidence: 0.4,
+		},
+		{
+			Words:      []string{"remove", "removed", "removes", "delete"},
+			Type:       "chore",
+			Imperative: "remove",
+			Confidence: 0.4,
+		},
+		{
+			Words:      []string{"change", "changed", "changes"},
+			Type:       "chore",
+			Imperative: "change",
+			Confidence: 0.3,
+		},
+	}
+}
+
+// DefaultConvertTopics returns common topics mentioned in free-form headers,
+// mapped to the types of the Angular convention.
+func DefaultConvertTopics() []*ConvertTopic {
+	return []*ConvertTopic{
+		{
+			Words: []string{
+				"readme", "docs", "documentation", "changelog", "godoc",
+			},
+			Type:       "docs",
+			Confidence: 0.7,
+		},
+		{
+			Words:      []string{"test", "tests", "spec", "specs"},
+			Type:       "test",
+			Confidence: 0.75,
+		},
+		{
+			Words: []string{
+				"dependency", "dependencies", "deps", "makefile",
+				"dockerfile", "go.mod",
+			},
+			Type:       "build",
+			Confidence: 0.65,
+		},
+		{
+			Words: []string{
+				"ci", "travis", "jenkins", "workflow", "workflows", "pipeline",
+			},
+			Type:       "ci",
+			Confidence: 0.7,
+		},
+		{
+			Words:      []string{"typo", "typos"},
+			Type:       "fix",
+			Confidence: 0.5,
+		},
+		{
+			Words:      []string{"lint", "linter", "whitespace", "gofmt"},
+			Type:       "style",
+			Confidence: 0.5,
+		},
+		{
+			Words:      []string{"performance", "faster"},
+			Type:       "perf",
+			Confidence: 0.6,
+		},
+	}
+}
+
+// Convert converts the given message into a conventional commit message. The
+// given slash-separated paths are the files changed by the commit, and are
+// used to infer the scope. It returns nil for empty and autogenerated
+// messages, like merges, and for autosquash commits, which should not be
+// converted.
+//
+// Git's `Revert "<header>"` messages are converted to `revert: <header>`, with
+// the reverted commits listed in a "Refs" footer. When the header only
+// consists of a verb, like "Fixed", the first line of the body is moved into
+// the description instead, with half the confidence. Without a body, the
+// header is kept as the description, with zero confidence.
+func (s *Converter) Convert(msg *RawMessage, paths []string) *Conversion {
+	if len(msg.Paragraphs) == 0 || msg.Kind().Autogenerated() ||
+		msg.Autosquash() != nil {
+		return nil
+	}
+
+	header := msg.Paragraphs[0].Lines[0]
+
+	if gitRevertRegexp.Match(header.Content) {
+		return s.convertRevert(msg, header, msg.Revert())
+	}
+
+	if h, err := ParseHeader(header); err == nil && s.known(h.Type) {
+		scopes := make([]string, 0, len(h.Scopes))
+		for _, scope := range h.Scopes {
+			scopes = append(scopes, scope.Name)
+		}
+
+		return &Conversion{
+			Type:        h.Type,
+			Scopes:      scopes,
+			Description: h.Description,
+			Confidence:  1,
+			Message:     msg,
+		}
+	}
+
+	words := strings.Fields(string(header.Content))
+	r := &Conversion{Type: s.Fallback, Scopes: []string{}}
+
+	if len(words) > 0 {
+		if v := s.verb(words[0]); v != nil {
+			r.Type = v.Type
+			r.Confidence = v.Confidence
+			if v.Imperative == "" {
+				words = words[1:]
+			} else {
+				words[0] = v.Imperative
+			}
+		}
+	}
+
+	var borrowed *Line
+	if len(words) == 0 && len(msg.Paragraphs) > 1 {
+		borrowed = msg.Paragraphs[1].Lines[0]
+		words = strings.Fields(string(borrowed.Content))
+		r.Confidence /= 2
+	}
+
+	if t := s.topic(words); t != nil && t.Confidence > r.Confidence {
+		r.Type = t.Type
+		r.Confidence = t.Confidence
+	}
+
+	if s.Scopes != nil {
+		words, r.Scopes = s.scopes(words, paths)
+	}
+
+	r.Description = convertDescription(words)
+	if r.Description == "" {
+		r.Description = convertDescription(
+			strings.Fields(string(header.Content)),
+		)
+		r.Confidence = 0
+		borrowed = nil
+	}
+
+	h := r.Type
+	if len(r.Scopes) > 0 {
+		h += "(" + strings.Join(r.Scopes, ",") + ")"
+	}
+	if borrowed != nil {
+		// Remove the line used as description from the body, along with
+		// its paragraph if it is the only line in it.
+		start := borrowed.Number
+		if len(msg.Paragraphs[1].Lines) == 1 {
+			first := msg.Paragraphs[0].Lines
+			start = first[len(first)-1].Number + 1
+		}
+		msg = removeLines(msg, start, borrowed.Number)
+		header = msg.Lines[header.Number-1]
+	}
+	r.Message = replaceHeader(msg, header, h+": "+r.Description)
+
+	return r
+}
+
+// convertRevert converts a git revert message into a conventional revert
+// message.
+func (s *Converter) convertRevert(
+	msg *RawMessage,
+	header *Line,
+	rev *Revert,
+) *Conversion {
+	r := &Conversion{
+		Type:        "revert",
+		Scopes:      []string{},
+		Description: rev.Header,
+		Confidence:  1,
+		Message:     replaceHeader(msg, header, "revert: "+rev.Header),
+	}
+
+	if len(rev.SHAs) > 0 {
+		r.Message = r.Message.EditTrailers(&TrailerEdit{
+			Token: "Refs",
+			Value: strings.Join(rev.SHAs, ", "),
+		})
+	}
+
+	return r
+}
+
+// replaceHeader returns a new message with the content of the given header
+// line replaced.
+func replaceHeader(msg *RawMessage, header *Line, content string) *RawMessage {
+	b := msg.Lines[:header.Number-1].Bytes()
+	b = append(b, content...)
+	b = append(b, header.Break...)
+	b = append(b, msg.Lines[header.Number:].Bytes()...)
+
+	return NewRawMessage(b)
+}
+
+// removeLines returns a new message without the lines numbered from start to
+// end, inclusive. When the removed lines end the message, the message keeps
+// ending with the line break of the last removed line, or without one.
+func removeLines(msg *RawMessage, start, end int) *RawMessage {
+	b := msg.Lines[:start-1].Bytes()
+	rest := msg.Lines[end:]
+	if len(rest) == 0 && start > 1 {
+		b = b[:len(b)-len(msg.Lines[start-2].Break)]
+		b = append(b, msg.Lines[end-1].Break...)
+	}
+	b = append(b, rest.Bytes()...)
+
+	return NewRawMessage(b)
+}
+
+// known reports if the given type counts as already conventional.
+func (s *Converter) known(name string) bool {
+	if s.Types == nil {
+		return true
+	}
+
+	_, ok := s.Types.Get(name)
+
+	return ok
+}
+
+// verb returns the verb matching the given word, or nil.
+func (s *Converter) verb(word string) *ConvertVerb {
+	word = strings.ToLower(strings.TrimRight(word, ":,."))
+
+	for _, v := range s.Verbs {
+		for _, w := range v.Words {
+			if w == word {
+				return v
+			}
+		}
+	}
+
+	return nil
+}
+
+// topic returns the topic with the highest confidence matching any of the
+// given words, or nil.
+func (s *Converter) topic(words []string) *ConvertTopic {
+	var r *ConvertTopic
+
+	for _, word := range words {
+		word = strings.ToLower(strings.Trim(word, ":,.()'\"`"))
+
+		for _, t := range s.Topics {
+			if r != nil && t.Confidence <= r.Confidence {
+				continue
+			}
+			for _, w := range t.Words {
+				if w == word {
+					r = t
+
+					break
+				}
+			}
+		}
+	}
+
+	return r
+}
+
+// scopes returns the scopes for the given header words and changed paths,
+// along with the words with any trailing scope phrase removed.
+func (s *Converter) scopes(
+	words []string,
+	paths []string,
+) ([]string, []string) {
+	if n := len(words); n >= 3 {
+		name := strings.ToLower(strings.TrimRight(words[n-1], "."))
+		prep := strings.ToLower(words[n-2])
+
+		for _, p := range scopePrepositions {
+			if p != prep {
+				continue
+			}
+			if scope, ok := s.Scopes.Get(name); ok {
+				return words[:n-2], []string{scope.Name}
+			}
+		}
+	}
+
+	return words, s.Scopes.Infer(paths)
+}
+
+// convertDescription joins the given words into a description, lowercasing
+// the first letter unless the first word is an acronym, and removing any
+// trailing period.
+func convertDescription(words []string) string {
+	d := strings.TrimRight(strings.Join(words, " "), ".")
+
+	first, size := utf8.DecodeRuneInString(d)
+	next, _ := utf8.DecodeRuneInString(d[size:])
+	if unicode.IsUpper(first) && !unicode.IsUpper(next) {
+		d = string(unicode.ToLower(first)) + d[size:]
+	}
+
+	return d
+}
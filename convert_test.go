@@ -0,0 +1,204 @@
+package conventionalcommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConverter_Convert(t *testing.T) {
+	scopes := NewScopeRegistry(
+		&Scope{Name: "api", Paths: []string{"api"}},
+		&Scope{Name: "cli", Paths: []string{"cmd/cli"}},
+	)
+	tests := []struct {
+		name           string
+		scopes         *ScopeRegistry
+		message        string
+		paths          []string
+		want           string
+		wantConfidence float64
+	}{
+		{
+			name:           "strips fix verb",
+			message:        "Fixed login bug",
+			want:           "fix: login bug",
+			wantConfidence: 0.8,
+		},
+		{
+			name:           "imperative verb",
+			message:        "Added caching.",
+			want:           "feat: add caching",
+			wantConfidence: 0.7,
+		},
+		{
+			name:           "scope from trailing phrase",
+			scopes:         scopes,
+			message:        "Add caching to API",
+			want:           "feat(api): add caching",
+			wantConfidence: 0.7,
+		},
+		{
+			name:           "unknown scope in trailing phrase",
+			scopes:         scopes,
+			message:        "Add caching to DB",
+			want:           "feat: add caching to DB",
+			wantConfidence: 0.7,
+		},
+		{
+			name:           "scope from paths",
+			scopes:         scopes,
+			message:        "Fix crash on exit",
+			paths:          []string{"cmd/cli/main.go", "README.md"},
+			want:           "fix(cli): crash on exit",
+			wantConfidence: 0.8,
+		},
+		{
+			name:           "multiple scopes from paths",
+			scopes:         scopes,
+			message:        "Rename config option",
+			paths:          []string{"cmd/cli/main.go", "api/config.go"},
+			want:           "refactor(api,cli): rename config option",
+			wantConfidence: 0.6,
+		},
+		{
+			name:           "topic overrides weak verb",
+			message:        "Update README",
+			want:           "docs: update README",
+			wantConfidence: 0.7,
+		},
+		{
+			name:           "strong verb overrides topic",
+			message:        "Fix crash in docs generator",
+			want:           "fix: crash in docs generator",
+			wantConfidence: 0.8,
+		},
+		{
+			name:           "topic without verb",
+			message:        "More tests for the parser",
+			want:           "test: more tests for the parser",
+			wantConfidence: 0.75,
+		},
+		{
+			name:           "fallback",
+			message:        "Version 1.2.0",
+			want:           "chore: version 1.2.0",
+			wantConfidence: 0,
+		},
+		{
+			name:           "verb only",
+			message:        "Fixed",
+			want:           "fix: fixed",
+			wantConfidence: 0,
+		},
+		{
+			name:           "verb only with body",
+			message:        "Fixed\n\nThe login form crashed.\n",
+			want:           "fix: the login form crashed\n",
+			wantConfidence: 0.4,
+		},
+		{
+			name:           "verb only with body without line break",
+			message:        "Fixed\n\nthe login page",
+			want:           "fix: the login page",
+			wantConfidence: 0.4,
+		},
+		{
+			name: "verb only with longer body",
+			message: "Fixed\n\n" +
+				"The login form crashed.\nIt no longer does.\n\n" +
+				"Signed-off-by: A\n",
+			want: "fix: the login form crashed\n\nIt no longer does.\n\n" +
+				"Signed-off-by: A\n",
+			wantConfidence: 0.4,
+		},
+		{
+			name:           "verb only with body paragraphs",
+			message:        "Fixed\n\nthe login page\n\n\nMore details.\n",
+			want:           "fix: the login page\n\n\nMore details.\n",
+			wantConfidence: 0.4,
+		},
+		{
+			name: "git revert",
+			message: "Revert \"feat: add caching\"\n\n" +
+				"This reverts commit abcdef1234567.\n",
+			want: "revert: feat: add caching\n\n" +
+				"This reverts commit abcdef1234567.\n\n" +
+				"Refs: abcdef1234567\n",
+			wantConfidence: 1,
+		},
+		{
+			name:           "git revert without hash",
+			message:        "Revert \"Fixed login bug\"",
+			want:           "revert: Fixed login bug",
+			wantConfidence: 1,
+		},
+		{
+			name:           "keeps body",
+			message:        "\nfixes #12\r\n\r\nMore details.\r\n",
+			want:           "\nfix: #12\r\n\r\nMore details.\r\n",
+			wantConfidence: 0.8,
+		},
+		{
+			name:           "already conventional",
+			message:        "feat(api): add caching\n\nMore details.",
+			want:           "feat(api): add caching\n\nMore details.",
+			wantConfidence: 1,
+		},
+		{
+			name:           "unknown conventional type",
+			message:        "Fixes: handle empty input",
+			want:           "fix: handle empty input",
+			wantConfidence: 0.8,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewConverter()
+			c.Scopes = tt.scopes
+			msg := NewRawMessage([]byte(tt.message))
+
+			got := c.Convert(msg, tt.paths)
+
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want, got.Message.String())
+			assert.Equal(t, tt.wantConfidence, got.Confidence)
+		})
+	}
+}
+
+func TestConverter_Convert_Skipped(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+	}{
+		{name: "empty", message: " \n\n"},
+		{name: "merge", message: "Merge branch 'main' into feature"},
+		{name: "initial", message: "Initial commit"},
+		{name: "fixup", message: "fixup! feat: add caching"},
+		{name: "squash", message: "squash! Fixed login bug\n\nMore."},
+		{name: "amend", message: "amend! amend! Add caching"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewConverter().Convert(
+				NewRawMessage([]byte(tt.message)), nil,
+			)
+
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func TestConverter_Convert_Fields(t *testing.T) {
+	c := NewConverter()
+	c.Scopes = NewScopeRegistry(&Scope{Name: "api"})
+
+	got := c.Convert(NewRawMessage([]byte("Implemented paging for API")), nil)
+
+	require.NotNil(t, got)
+	assert.Equal(t, "feat", got.Type)
+	assert.Equal(t, []string{"api"}, got.Scopes)
+	assert.Equal(t, "implement paging", got.Description)
+}
@@ -0,0 +1,10 @@
+package conventionalcommit
+
+// Diagnostic is a problem found in a commit message, like a lint violation.
+type Diagnostic struct {
+	// Span is the location of the problem within the message.
+	Span *Span
+
+	// Message is a short human readable description of the problem.
+	Message string
+}
@@ -0,0 +1,178 @@
+package conventionalcommit
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Fix is a suggested edit to a commit message, replacing the text covered by
+// Span with Replacement. A Span where Start and End are equal inserts the
+// replacement at that position. Replacements may contain line breaks.
+type Fix struct {
+	// Span is the location of the text to replace.
+	Span *Span
+
+	// Replacement is the text which replaces the text covered by Span.
+	Replacement string
+
+	// Description is a short human readable description of the fix.
+	Description string
+}
+
+// Diagnostic returns a Diagnostic for the problem the fix corrects, located at
+// the span of the fix.
+func (s *Fix) Diagnostic() *Diagnostic {
+	return &Diagnostic{Span: s.Span, Message: s.Description}
+}
+
+// ApplyFixes returns a new message with the given fixes applied. Fixes which
+// overlap an earlier fix, in order of position within the message, are not
+// applied, and are returned instead. Their spans refer to the original message,
+// so they need to be re-created against the new message before they can be
+// applied. Fixes with spans which are not within a line of the message are
+// returned too. Nil fixes are ignored, so the result of fix constructors which
+// return nil when there is nothing to fix can be passed as is.
+func (s *RawMessage) ApplyFixes(fixes ...*Fix) (*RawMessage, []*Fix) {
+	skipped := []*Fix{}
+	sorted := make([]*Fix, 0, len(fixes))
+	for _, f := range fixes {
+		if f == nil {
+			continue
+		}
+		if s.validSpan(f.Span) {
+			sorted = append(sorted, f)
+		} else {
+			skipped = append(skipped, f)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := sorted[i].Span, sorted[j].Span
+		if a.Line.Number != b.Line.Number {
+			return a.Line.Number < b.Line.Number
+		}
+
+		return a.Start < b.Start
+	})
+
+	offsets := make([]int, len(s.Lines))
+	offset := 0
+	for i, l := range s.Lines {
+		offsets[i] = offset
+		offset += len(l.Content) + len(l.Break)
+	}
+
+	orig := s.Bytes()
+	b := make([]byte, 0, len(orig))
+
+	pos := 0
+	var prev *Span
+	for _, f := range sorted {
+		if prev != nil && spansOverlap(prev, f.Span) {
+			skipped = append(skipped, f)
+
+			continue
+		}
+
+		start := offsets[f.Span.Line.Number-1] + f.Span.Start
+		end := offsets[f.Span.Line.Number-1] + f.Span.End
+
+		b = append(b, orig[pos:start]...)
+		b = append(b, f.Replacement...)
+		pos = end
+		prev = f.Span
+	}
+	b = append(b, orig[pos:]...)
+
+	return NewRawMessage(b), skipped
+}
+
+// validSpan reports if the given span is within one of the lines of the
+// message.
+func (s *RawMessage) validSpan(span *Span) bool {
+	if span == nil || span.Line == nil {
+		return false
+	}
+
+	n := span.Line.Number
+	if n < 1 || n > len(s.Lines) || s.Lines[n-1] != span.Line {
+		return false
+	}
+
+	return 0 <= span.Start && span.Start <= span.End &&
+		span.End <= len(span.Line.Content)
+}
+
+// TypeCaseFix returns a Fix which lowercases the type of the header, or nil if
+// it is already lowercase, or is not written out in the header.
+func (s *Header) TypeCaseFix() *Fix {
+	if s.TypeSpan == nil {
+		return nil
+	}
+
+	typ := s.TypeSpan.String()
+	lower := strings.ToLower(typ)
+	if typ == lower {
+		return nil
+	}
+
+	return &Fix{
+		Span:        s.TypeSpan,
+		Replacement: lower,
+		Description: fmt.Sprintf("type %q must be lowercase", typ),
+	}
+}
+
+// TrailingPeriodFix returns a Fix which removes a trailing period from the
+// description of the header, or nil if there is none. Ellipses are kept.
+func (s *Header) TrailingPeriodFix() *Fix {
+	if !strings.HasSuffix(s.Description, ".") ||
+		strings.HasSuffix(s.Description, "...") {
+		return nil
+	}
+
+	content := s.Line.Content
+	start := bytes.LastIndex(content, []byte(s.Description))
+	if start < 0 {
+		return nil
+	}
+	end := start + len(s.Description)
+
+	return &Fix{
+		Span:        &Span{Line: s.Line, Start: end - 1, End: end},
+		Replacement: "",
+		Description: "description must not end with a period",
+	}
+}
+
+// HeaderBlankLineFix returns a Fix which inserts a blank line between the
+// header and the body of the message, or nil if there already is one.
+func (s *RawMessage) HeaderBlankLineFix() *Fix {
+	if len(s.Paragraphs) == 0 || len(s.Paragraphs[0].Lines) < 2 {
+		return nil
+	}
+
+	header := s.Paragraphs[0].Lines[0]
+
+	return &Fix{
+		Span: &Span{
+			Line:  header,
+			Start: len(header.Content),
+			End:   len(header.Content),
+		},
+		Replacement: string(s.lineBreak()),
+		Description: "header must be followed by a blank line",
+	}
+}
+
+// spansOverlap reports if span b overlaps span a, where b does not start before
+// a. Two insertions at the same position also overlap, as their order would be
+// ambiguous.
+func spansOverlap(a *Span, b *Span) bool {
+	if a.Line.Number != b.Line.Number {
+		return false
+	}
+
+	return b.Start < a.End || b.Start == a.Start
+}
@@ -0,0 +1,315 @@
+package conventionalcommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawMessage_ApplyFixes(t *testing.T) {
+	type fix struct {
+		line        int
+		start       int
+		end         int
+		replacement string
+	}
+	tests := []struct {
+		name        string
+		message     string
+		fixes       []fix
+		want        string
+		wantSkipped []int
+	}{
+		{
+			name:        "no fixes",
+			message:     "Feat: add a thing.",
+			fixes:       []fix{},
+			want:        "Feat: add a thing.",
+			wantSkipped: []int{},
+		},
+		{
+			name:    "lowercase type and remove trailing period",
+			message: "Feat: add a thing.",
+			fixes: []fix{
+				{line: 1, start: 17, end: 18, replacement: ""},
+				{line: 1, start: 0, end: 4, replacement: "feat"},
+			},
+			want:        "feat: add a thing",
+			wantSkipped: []int{},
+		},
+		{
+			name:    "insert blank line after header",
+			message: "feat: add a thing\r\nMore details.\r\n",
+			fixes: []fix{
+				{line: 1, start: 17, end: 17, replacement: "\r\n"},
+			},
+			want:        "feat: add a thing\r\n\r\nMore details.\r\n",
+			wantSkipped: []int{},
+		},
+		{
+			name:    "multiple lines",
+			message: "Feat: add a thing\n\nMore details\n\nrefs: #1",
+			fixes: []fix{
+				{line: 5, start: 0, end: 4, replacement: "Refs"},
+				{line: 3, start: 12, end: 12, replacement: "."},
+				{line: 1, start: 0, end: 4, replacement: "feat"},
+			},
+			want:        "feat: add a thing\n\nMore details.\n\nRefs: #1",
+			wantSkipped: []int{},
+		},
+		{
+			name:    "overlapping fixes",
+			message: "Feat: add a thing.",
+			fixes: []fix{
+				{line: 1, start: 0, end: 4, replacement: "feat"},
+				{line: 1, start: 2, end: 6, replacement: "x"},
+				{line: 1, start: 4, end: 4, replacement: "(api)"},
+			},
+			want:        "feat(api): add a thing.",
+			wantSkipped: []int{1},
+		},
+		{
+			name:    "insertions at the same position",
+			message: "feat: add a thing",
+			fixes: []fix{
+				{line: 1, start: 4, end: 4, replacement: "(api)"},
+				{line: 1, start: 4, end: 4, replacement: "!"},
+			},
+			want:        "feat(api): add a thing",
+			wantSkipped: []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewRawMessage([]byte(tt.message))
+			fixes := []*Fix{}
+			for _, f := range tt.fixes {
+				fixes = append(fixes, &Fix{
+					Span: &Span{
+						Line:  msg.Lines[f.line-1],
+						Start: f.start,
+						End:   f.end,
+					},
+					Replacement: f.replacement,
+				})
+			}
+
+			got, skipped := msg.ApplyFixes(fixes...)
+
+			assert.Equal(t, tt.want, got.String())
+			wantSkipped := []*Fix{}
+			for _, i := range tt.wantSkipped {
+				wantSkipped = append(wantSkipped, fixes[i])
+			}
+			assert.Equal(t, wantSkipped, skipped)
+		})
+	}
+}
+
+func TestRawMessage_ApplyFixes_InvalidSpans(t *testing.T) {
+	msg := NewRawMessage([]byte("Feat: add a thing."))
+	other := NewRawMessage([]byte("a\nb\nc"))
+	line := msg.Lines[0]
+	fixes := []*Fix{
+		{Span: &Span{Line: other.Lines[2], Start: 0, End: 1}},
+		{Span: &Span{Line: other.Lines[0], Start: 0, End: 1}},
+		{Span: &Span{Line: &Line{Number: 1}, Start: 0, End: 0}},
+		{Span: &Span{Line: line, Start: -1, End: 1}},
+		{Span: &Span{Line: line, Start: 10, End: 99}},
+		{Span: &Span{Line: line, Start: 4, End: 2}},
+		{Span: &Span{Start: 0, End: 0}},
+		{},
+		{Span: &Span{Line: line, Start: 0, End: 4}, Replacement: "feat"},
+	}
+
+	got, skipped := msg.ApplyFixes(fixes...)
+
+	assert.Equal(t, "feat: add a thing.", got.String())
+	assert.Equal(t, fixes[:8], skipped)
+}
+
+func TestHeader_TypeCaseFix(t *testing.T) {
+	tests := []struct {
+		name    string
+		parser  *HeaderParser
+		header  string
+		want    string
+		wantNil bool
+	}{
+		{
+			name:   "uppercase",
+			header: "FEAT(api): add a thing",
+			want:   "feat(api): add a thing",
+		},
+		{
+			name:   "mixed case",
+			header: "Fix: a broken thing",
+			want:   "fix: a broken thing",
+		},
+		{
+			name:    "lowercase",
+			header:  "feat: add a thing",
+			wantNil: true,
+		},
+		{
+			name:    "type from gitmoji",
+			parser:  &HeaderParser{Gitmoji: DefaultGitmojiTable()},
+			header:  "✨ Add a thing",
+			wantNil: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := tt.parser
+			if parser == nil {
+				parser = &HeaderParser{}
+			}
+			msg := NewRawMessage([]byte(tt.header))
+			h, err := parser.Parse(msg.Lines[0])
+			require.NoError(t, err)
+
+			fix := h.TypeCaseFix()
+
+			if tt.wantNil {
+				assert.Nil(t, fix)
+
+				return
+			}
+
+			require.NotNil(t, fix)
+			got, skipped := msg.ApplyFixes(fix)
+			assert.Empty(t, skipped)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestHeader_TrailingPeriodFix(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		want    string
+		wantNil bool
+	}{
+		{
+			name:   "trailing period",
+			header: "feat: add a thing.",
+			want:   "feat: add a thing",
+		},
+		{
+			name:   "trailing period and whitespace",
+			header: "feat: add a thing.  ",
+			want:   "feat: add a thing  ",
+		},
+		{
+			name:    "no period",
+			header:  "feat: add a thing",
+			wantNil: true,
+		},
+		{
+			name:    "ellipsis",
+			header:  "feat: add a thing...",
+			wantNil: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewRawMessage([]byte(tt.header))
+			h, err := msg.Header()
+			require.NoError(t, err)
+
+			fix := h.TrailingPeriodFix()
+
+			if tt.wantNil {
+				assert.Nil(t, fix)
+
+				return
+			}
+
+			require.NotNil(t, fix)
+			got, skipped := msg.ApplyFixes(fix)
+			assert.Empty(t, skipped)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestRawMessage_HeaderBlankLineFix(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    string
+		wantNil bool
+	}{
+		{
+			name:    "missing blank line",
+			message: "feat: add a thing\nMore details.\n",
+			want:    "feat: add a thing\n\nMore details.\n",
+		},
+		{
+			name:    "CRLF",
+			message: "\r\nfeat: add a thing\r\nMore details.",
+			want:    "\r\nfeat: add a thing\r\n\r\nMore details.",
+		},
+		{
+			name:    "blank line",
+			message: "feat: add a thing\n\nMore details.",
+			wantNil: true,
+		},
+		{
+			name:    "header only",
+			message: "feat: add a thing\n",
+			wantNil: true,
+		},
+		{
+			name:    "empty",
+			message: "",
+			wantNil: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewRawMessage([]byte(tt.message))
+
+			fix := msg.HeaderBlankLineFix()
+
+			if tt.wantNil {
+				assert.Nil(t, fix)
+
+				return
+			}
+
+			require.NotNil(t, fix)
+			got, skipped := msg.ApplyFixes(fix)
+			assert.Empty(t, skipped)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestRawMessage_ApplyFixes_All(t *testing.T) {
+	msg := NewRawMessage([]byte("Feat(api): add a thing.\nMore details.\n"))
+	h, err := msg.Header()
+	require.NoError(t, err)
+
+	got, skipped := msg.ApplyFixes(
+		h.TypeCaseFix(), h.TrailingPeriodFix(), msg.HeaderBlankLineFix(),
+	)
+
+	assert.Empty(t, skipped)
+	assert.Equal(t, "feat(api): add a thing\n\nMore details.\n", got.String())
+}
+
+func TestRawMessage_ApplyFixes_Nil(t *testing.T) {
+	msg := NewRawMessage([]byte("feat(api): add a thing\n\nMore details.\n"))
+	h, err := msg.Header()
+	require.NoError(t, err)
+
+	got, skipped := msg.ApplyFixes(
+		h.TypeCaseFix(), h.TrailingPeriodFix(), msg.HeaderBlankLineFix(), nil,
+	)
+
+	assert.Empty(t, skipped)
+	assert.Equal(t, msg.String(), got.String())
+}
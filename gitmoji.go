@@ -0,0 +1,191 @@
+package conventionalcommit
+
+import (
+	"bytes"
+	"strings"
+)
+
+// variationSelector is the Unicode variation selector which requests emoji
+// presentation of the preceding character. Many gitmoji include it, but it is
+// often omitted when typed by hand.
+const variationSelector = "\ufe0f"
+
+// Gitmoji describes a single gitmoji (https://gitmoji.dev), and the
+// conventional commit type it corresponds to.
+type Gitmoji struct {
+	// Emoji is the Unicode form of the gitmoji, for example "✨".
+	Emoji string
+
+	// Code is the shortcode form of the gitmoji, for example ":sparkles:".
+	Code string
+
+	// Type is the conventional commit type the gitmoji corresponds to, for
+	// example "feat". It may be empty if there is no corresponding type.
+	Type string
+
+	// Breaking indicates the gitmoji marks a breaking change.
+	Breaking bool
+}
+
+// GitmojiStyle is the form gitmoji are written in.
+type GitmojiStyle int
+
+const (
+	// GitmojiUnicode writes gitmoji as Unicode emoji, for example "✨".
+	GitmojiUnicode GitmojiStyle = iota
+
+	// GitmojiShortcode writes gitmoji as shortcodes, for example
+	// ":sparkles:".
+	GitmojiShortcode
+)
+
+// GitmojiTable maps gitmoji to conventional commit types.
+type GitmojiTable struct {
+	gitmojis []*Gitmoji
+}
+
+// NewGitmojiTable returns a GitmojiTable containing the given gitmoji.
+func NewGitmojiTable(gitmojis ...*Gitmoji) *GitmojiTable {
+	r := &GitmojiTable{gitmojis: []*Gitmoji{}}
+
+	for _, g := range gitmojis {
+		r.Add(g)
+	}
+
+	return r
+}
+
+// DefaultGitmojiTable returns a new GitmojiTable containing common gitmoji and
+// the Angular types they correspond to.
+func DefaultGitmojiTable() *GitmojiTable {
+	return NewGitmojiTable(DefaultGitmojis()...)
+}
+
+// DefaultGitmojis returns common gitmoji, mapped to the closest type of the
+// Angular convention.
+func DefaultGitmojis() []*Gitmoji {
+	return []*Gitmoji{
+		{Emoji: "✨", Code: ":sparkles:", Type: "feat"},
+		{Emoji: "💥", Code: ":boom:", Type: "feat", Breaking: true},
+		{Emoji: "💄", Code: ":lipstick:", Type: "feat"},
+		{Emoji: "🐛", Code: ":bug:", Type: "fix"},
+		{Emoji: "🚑️", Code: ":ambulance:", Type: "fix"},
+		{Emoji: "🩹", Code: ":adhesive_bandage:", Type: "fix"},
+		{Emoji: "🔒️", Code: ":lock:", Type: "fix"},
+		{Emoji: "✏️", Code: ":pencil2:", Type: "fix"},
+		{Emoji: "⚡️", Code: ":zap:", Type: "perf"},
+		{Emoji: "⏪️", Code: ":rewind:", Type: "revert"},
+		{Emoji: "📝", Code: ":memo:", Type: "docs"},
+		{Emoji: "🎨", Code: ":art:", Type: "style"},
+		{Emoji: "🚨", Code: ":rotating_light:", Type: "style"},
+		{Emoji: "♻️", Code: ":recycle:", Type: "refactor"},
+		{Emoji: "🔥", Code: ":fire:", Type: "refactor"},
+		{Emoji: "🏗️", Code: ":building_construction:", Type: "refactor"},
+		{Emoji: "✅", Code: ":white_check_mark:", Type: "test"},
+		{Emoji: "🧪", Code: ":test_tube:", Type: "test"},
+		{Emoji: "📦️", Code: ":package:", Type: "build"},
+		{Emoji: "⬆️", Code: ":arrow_up:", Type: "build"},
+		{Emoji: "⬇️", Code: ":arrow_down:", Type: "build"},
+		{Emoji: "➕", Code: ":heavy_plus_sign:", Type: "build"},
+		{Emoji: "➖", Code: ":heavy_minus_sign:", Type: "build"},
+		{Emoji: "👷", Code: ":construction_worker:", Type: "ci"},
+		{Emoji: "💚", Code: ":green_heart:", Type: "ci"},
+		{Emoji: "🔧", Code: ":wrench:", Type: "chore"},
+		{Emoji: "🔖", Code: ":bookmark:", Type: "chore"},
+		{Emoji: "🙈", Code: ":see_no_evil:", Type: "chore"},
+	}
+}
+
+// Add adds the given gitmoji to the table. If a gitmoji with the same emoji or
+// shortcode already exists, it is replaced.
+func (s *GitmojiTable) Add(gitmoji *Gitmoji) {
+	for i, g := range s.gitmojis {
+		if (g.Code != "" && g.Code == gitmoji.Code) ||
+			sameEmoji(g.Emoji, gitmoji.Emoji) {
+			s.gitmojis[i] = gitmoji
+
+			return
+		}
+	}
+
+	s.gitmojis = append(s.gitmojis, gitmoji)
+}
+
+// Gitmojis returns all gitmoji in the table, in the order they were added.
+func (s *GitmojiTable) Gitmojis() []*Gitmoji {
+	r := make([]*Gitmoji, len(s.gitmojis))
+	copy(r, s.gitmojis)
+
+	return r
+}
+
+// Prefix returns the gitmoji at the very start of the given content in either
+// Unicode or shortcode form, along with its length in bytes. It returns nil and
+// zero if the content does not start with a known gitmoji. The Unicode
+// variation selector is optional in Unicode gitmoji.
+func (s *GitmojiTable) Prefix(content []byte) (*Gitmoji, int) {
+	for _, g := range s.gitmojis {
+		if g.Code != "" && bytes.HasPrefix(content, []byte(g.Code)) {
+			return g, len(g.Code)
+		}
+
+		emoji := strings.TrimSuffix(g.Emoji, variationSelector)
+		if emoji == "" || !bytes.HasPrefix(content, []byte(emoji)) {
+			continue
+		}
+
+		n := len(emoji)
+		if bytes.HasPrefix(content[n:], []byte(variationSelector)) {
+			n += len(variationSelector)
+		}
+
+		return g, n
+	}
+
+	return nil, 0
+}
+
+// Convert returns a new message with the leading gitmoji of its header written
+// in the given style. Messages without a known leading gitmoji are returned
+// unchanged.
+func (s *GitmojiTable) Convert(
+	msg *RawMessage,
+	style GitmojiStyle,
+) *RawMessage {
+	if len(msg.Paragraphs) == 0 {
+		return msg
+	}
+
+	header := msg.Paragraphs[0].Lines[0]
+	g, n := s.Prefix(header.Content)
+	if g == nil {
+		return msg
+	}
+
+	replacement := g.Emoji
+	if style == GitmojiShortcode {
+		replacement = g.Code
+	}
+	if replacement == "" {
+		return msg
+	}
+
+	before := msg.Lines[:header.Number-1].Bytes()
+	after := msg.Lines[header.Number-1:].Bytes()[n:]
+
+	b := make([]byte, 0, len(before)+len(replacement)+len(after))
+	b = append(b, before...)
+	b = append(b, replacement...)
+	b = append(b, after...)
+
+	return NewRawMessage(b)
+}
+
+// sameEmoji reports if a and b are the same emoji, ignoring variation
+// selectors.
+func sameEmoji(a, b string) bool {
+	a = strings.TrimSuffix(a, variationSelector)
+	b = strings.TrimSuffix(b, variationSelector)
+
+	return a != "" && a == b
+}
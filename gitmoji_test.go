@@ -0,0 +1,274 @@
+package conventionalcommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitmojiTable_Add(t *testing.T) {
+	table := NewGitmojiTable(
+		&Gitmoji{Emoji: "✨", Code: ":sparkles:", Type: "feat"},
+		&Gitmoji{Emoji: "⚡️", Code: ":zap:", Type: "perf"},
+	)
+
+	table.Add(&Gitmoji{Emoji: "🔥", Code: ":fire:", Type: "chore"})
+	table.Add(&Gitmoji{Emoji: "✨", Code: ":sparkles:", Type: "feature"})
+	table.Add(&Gitmoji{Emoji: "⚡", Code: ":high_voltage:", Type: "perf"})
+
+	assert.Equal(t,
+		[]*Gitmoji{
+			{Emoji: "✨", Code: ":sparkles:", Type: "feature"},
+			{Emoji: "⚡", Code: ":high_voltage:", Type: "perf"},
+			{Emoji: "🔥", Code: ":fire:", Type: "chore"},
+		},
+		table.Gitmojis(),
+	)
+}
+
+func TestGitmojiTable_Prefix(t *testing.T) {
+	table := DefaultGitmojiTable()
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantLen int
+	}{
+		{
+			name:    "none",
+			content: "feat: add a thing",
+		},
+		{
+			name:    "not at start",
+			content: "feat: ✨ add a thing",
+		},
+		{
+			name:    "unknown shortcode",
+			content: ":nope: add a thing",
+		},
+		{
+			name:    "unicode",
+			content: "✨ add a thing",
+			want:    ":sparkles:",
+			wantLen: len("✨"),
+		},
+		{
+			name:    "shortcode",
+			content: ":sparkles: add a thing",
+			want:    ":sparkles:",
+			wantLen: len(":sparkles:"),
+		},
+		{
+			name:    "with variation selector",
+			content: "⚡️ speed up a thing",
+			want:    ":zap:",
+			wantLen: len("⚡️"),
+		},
+		{
+			name:    "without variation selector",
+			content: "⚡ speed up a thing",
+			want:    ":zap:",
+			wantLen: len("⚡"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, n := table.Prefix([]byte(tt.content))
+
+			if tt.want == "" {
+				assert.Nil(t, got)
+				assert.Equal(t, 0, n)
+
+				return
+			}
+
+			require.NotNil(t, got)
+			assert.Equal(t, tt.want, got.Code)
+			assert.Equal(t, tt.wantLen, n)
+		})
+	}
+}
+
+func TestGitmojiTable_Convert(t *testing.T) {
+	table := NewGitmojiTable(
+		&Gitmoji{Emoji: "✨", Code: ":sparkles:", Type: "feat"},
+		&Gitmoji{Emoji: "⚡️", Code: ":zap:", Type: "perf"},
+		&Gitmoji{Emoji: "🥚", Type: "chore"},
+	)
+
+	tests := []struct {
+		name    string
+		message string
+		style   GitmojiStyle
+		want    string
+	}{
+		{
+			name:    "empty",
+			message: "",
+			style:   GitmojiShortcode,
+			want:    "",
+		},
+		{
+			name:    "no gitmoji",
+			message: "feat: add a thing ✨",
+			style:   GitmojiShortcode,
+			want:    "feat: add a thing ✨",
+		},
+		{
+			name:    "unicode to shortcode",
+			message: "\n✨ feat: add a thing\n\n✨ More details.\n",
+			style:   GitmojiShortcode,
+			want:    "\n:sparkles: feat: add a thing\n\n✨ More details.\n",
+		},
+		{
+			name:    "shortcode to unicode",
+			message: ":zap: perf: speed up a thing",
+			style:   GitmojiUnicode,
+			want:    "⚡️ perf: speed up a thing",
+		},
+		{
+			name:    "unicode to unicode",
+			message: "⚡ perf: speed up a thing",
+			style:   GitmojiUnicode,
+			want:    "⚡️ perf: speed up a thing",
+		},
+		{
+			name:    "no shortcode",
+			message: "🥚 chore: hatch a thing",
+			style:   GitmojiShortcode,
+			want:    "🥚 chore: hatch a thing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewRawMessage([]byte(tt.message))
+
+			got := table.Convert(msg, tt.style)
+
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestHeaderParser_Parse_Gitmoji(t *testing.T) {
+	parser := &HeaderParser{Gitmoji: DefaultGitmojiTable()}
+
+	tests := []struct {
+		name        string
+		header      string
+		gitmoji     string
+		typ         string
+		scopes      []string
+		breaking    bool
+		description string
+		wantErr     string
+	}{
+		{
+			name:        "no gitmoji",
+			header:      "feat(api): add a thing",
+			typ:         "feat",
+			scopes:      []string{"api"},
+			description: "add a thing",
+		},
+		{
+			name:        "gitmoji and type",
+			header:      "🐛 fix(api): a broken thing",
+			gitmoji:     ":bug:",
+			typ:         "fix",
+			scopes:      []string{"api"},
+			description: "a broken thing",
+		},
+		{
+			name:        "gitmoji with different type",
+			header:      ":sparkles: docs: add a thing",
+			gitmoji:     ":sparkles:",
+			typ:         "docs",
+			scopes:      []string{},
+			description: "add a thing",
+		},
+		{
+			name:        "gitmoji without type",
+			header:      "✨ add a thing",
+			gitmoji:     ":sparkles:",
+			typ:         "feat",
+			scopes:      []string{},
+			description: "add a thing",
+		},
+		{
+			name:        "breaking gitmoji",
+			header:      ":boom: feat(api): remove a thing",
+			gitmoji:     ":boom:",
+			typ:         "feat",
+			scopes:      []string{"api"},
+			breaking:    true,
+			description: "remove a thing",
+		},
+		{
+			name:    "gitmoji without description",
+			header:  "✨  ",
+			wantErr: "invalid header: missing description",
+		},
+		{
+			name:        "gitmoji with plain description",
+			header:      "✨ Add (optional) things!",
+			gitmoji:     ":sparkles:",
+			typ:         "feat",
+			scopes:      []string{},
+			description: "Add (optional) things!",
+		},
+		{
+			name:    "gitmoji and unclosed scope",
+			header:  "✨ feat(api: add a thing",
+			wantErr: "invalid header: unclosed scope",
+		},
+		{
+			name:    "gitmoji and missing space",
+			header:  "✨ feat!:add a thing",
+			wantErr: `invalid header: missing ": " after type and scope`,
+		},
+		{
+			name:    "gitmoji and type without description",
+			header:  "🐛 fix: ",
+			wantErr: "invalid header: missing description",
+		},
+		{
+			name:    "unknown gitmoji",
+			header:  ":nope: add a thing",
+			wantErr: "invalid header: missing type",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := &Line{Number: 1, Content: []byte(tt.header)}
+
+			got, err := parser.Parse(line)
+
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrInvalidHeader)
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.gitmoji == "" {
+				assert.Nil(t, got.Gitmoji)
+			} else {
+				require.NotNil(t, got.Gitmoji)
+				assert.Equal(t, tt.gitmoji, got.Gitmoji.Code)
+			}
+			assert.Equal(t, tt.typ, got.Type)
+			assert.Equal(t, tt.breaking, got.Breaking)
+			assert.Equal(t, tt.description, got.Description)
+
+			scopes := []string{}
+			for _, s := range got.Scopes {
+				scopes = append(scopes, s.Span.String())
+			}
+			assert.Equal(t, tt.scopes, scopes)
+		})
+	}
+}
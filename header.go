@@ -0,0 +1,360 @@
+package conventionalcommit
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidHeader is returned when a header does not follow the conventional
+// commit format.
+var ErrInvalidHeader = errors.New("invalid header")
+
+// Header represents the parsed header (first line) of a conventional commit
+// message, defined as; A type, optionally followed by one or more scopes within
+// parentheses, an optional "!" breaking change marker, a ": " separator, and a
+// description.
+type Header struct {
+	// Type is the commit type, for example "feat" or "fix".
+	Type string
+
+	// TypeSpan is the location of the type within the header line. It is
+	// nil when the type is derived from a gitmoji rather than written out.
+	TypeSpan *Span
+
+	// Scopes is the list of scopes within the parentheses after the type.
+	// It is empty if the header has no scope.
+	Scopes []*HeaderScope
+
+	// Breaking is true if the header includes the "!" breaking change marker.
+	Breaking bool
+
+	// Description is the text after the ": " separator.
+	Description string
+
+	// Gitmoji is the leading gitmoji of the header, if any. Only set when
+	// parsed with a HeaderParser which has a GitmojiTable.
+	Gitmoji *Gitmoji
+
+	// Fields holds the values of any additional named groups of a custom
+	// header pattern, for example a ticket key. It is empty for headers
+	// parsed with the standard grammar.
+	Fields map[string]string
+
+	// Line is the line the header was parsed from.
+	Line *Line
+}
+
+// HeaderScope represents a single scope within a header. Nested scopes like
+// "api/users" have one part for each level of the hierarchy.
+type HeaderScope struct {
+	// Name is the full scope as written, for example "api/users".
+	Name string
+
+	// Parts is the hierarchy of the scope, for example ["api", "users"].
+	Parts []string
+
+	// Span is the location of the scope within the header line.
+	Span *Span
+}
+
+// HeaderParser parses conventional commit headers. The zero value is ready to
+// use, and uses the default delimiter and separator.
+type HeaderParser struct {
+	// ScopeDelimiters is the set of characters which separate multiple scopes,
+	// as in "feat(api,cli): ...". Defaults to ",".
+	ScopeDelimiters string
+
+	// ScopeSeparator separates the levels of nested scopes, as in
+	// "fix(api/users): ...". Defaults to "/".
+	ScopeSeparator string
+
+	// Pattern replaces the standard header grammar when set. See
+	// CompileHeaderPattern for details.
+	Pattern *regexp.Regexp
+
+	// Gitmoji enables recognition of a leading gitmoji when set. Headers may
+	// then be written as "✨ feat: add a thing", or as "✨ add a thing", in
+	// which case the type is taken from the table. Gitmoji are not
+	// recognized when Pattern is set, as patterns can capture them directly.
+	Gitmoji *GitmojiTable
+}
+
+// CompileHeaderPattern compiles a regular expression for use as a custom header
+// grammar in HeaderParser. The expression must have named "type" and
+// "description" groups, and may have "scope" and "breaking" groups. Scopes are
+// split with the parser's delimiters and separator, and a non-empty "breaking"
+// group marks the header as breaking. Values of all other named groups are
+// stored in Header.Fields.
+//
+// For example, headers like "feat: PROJ-123 add a thing" can be parsed with:
+//
+//	^(?P<type>\w+): (?P<ticket>[A-Z]+-\d+) (?P<description>.+)$
+func CompileHeaderPattern(expr string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range []string{"type", "description"} {
+		if re.SubexpIndex(name) < 0 {
+			return nil, fmt.Errorf(
+				"header pattern is missing a named %q group", name,
+			)
+		}
+	}
+
+	return re, nil
+}
+
+// ParseHeader parses the given line with the default HeaderParser.
+func ParseHeader(line *Line) (*Header, error) {
+	return (&HeaderParser{}).Parse(line)
+}
+
+// Header parses the header of the message with the default HeaderParser.
+func (s *RawMessage) Header() (*Header, error) {
+	if len(s.Paragraphs) == 0 {
+		return nil, fmt.Errorf("%w: message is empty", ErrInvalidHeader)
+	}
+
+	return ParseHeader(s.Paragraphs[0].Lines[0])
+}
+
+// Parse parses the given line as a conventional commit header.
+func (s *HeaderParser) Parse(line *Line) (*Header, error) {
+	if s.Pattern != nil {
+		return s.parsePattern(line)
+	}
+
+	if s.Gitmoji == nil {
+		return s.parseStandard(line, 0)
+	}
+
+	gitmoji, n := s.Gitmoji.Prefix(line.Content)
+	if gitmoji == nil {
+		return s.parseStandard(line, 0)
+	}
+
+	start := n
+	for start < len(line.Content) && isSpace(line.Content[start]) {
+		start++
+	}
+
+	h, err := s.parseStandard(line, start)
+	if err != nil {
+		// Headers like "✨ add a thing" take their type from the gitmoji,
+		// unless they look like a malformed conventional header.
+		if gitmoji.Type == "" || hasTypePrefix(line.Content[start:]) {
+			return nil, err
+		}
+
+		h = &Header{
+			Type:        gitmoji.Type,
+			Scopes:      []*HeaderScope{},
+			Description: string(bytes.TrimSpace(line.Content[start:])),
+			Fields:      map[string]string{},
+			Line:        line,
+		}
+		if h.Description == "" {
+			return nil, fmt.Errorf(
+				"%w: missing description", ErrInvalidHeader,
+			)
+		}
+	}
+
+	h.Gitmoji = gitmoji
+	h.Breaking = h.Breaking || gitmoji.Breaking
+
+	return h, nil
+}
+
+// hasTypePrefix reports if content starts with a type followed by "(", "!" or
+// ":", the start of a conventional commit header.
+func hasTypePrefix(content []byte) bool {
+	i := 0
+	for i < len(content) && isTokenByte(content[i]) {
+		i++
+	}
+
+	return i > 0 && i < len(content) &&
+		(content[i] == '(' || content[i] == '!' || content[i] == ':')
+}
+
+// parseStandard parses the given line using the standard conventional commit
+// header grammar, starting at the given offset.
+func (s *HeaderParser) parseStandard(line *Line, start int) (*Header, error) {
+	content := line.Content
+	h := &Header{
+		Scopes: []*HeaderScope{},
+		Fields: map[string]string{},
+		Line:   line,
+	}
+
+	i := start
+	for i < len(content) && isTokenByte(content[i]) {
+		i++
+	}
+	if i == start {
+		return nil, fmt.Errorf("%w: missing type", ErrInvalidHeader)
+	}
+	h.Type = string(content[start:i])
+	h.TypeSpan = &Span{Line: line, Start: start, End: i}
+
+	if i < len(content) && content[i] == '(' {
+		end := bytes.IndexByte(content[i:], ')')
+		if end < 0 {
+			return nil, fmt.Errorf("%w: unclosed scope", ErrInvalidHeader)
+		}
+
+		scopes, err := s.parseScopes(line, i+1, i+end)
+		if err != nil {
+			return nil, err
+		}
+
+		h.Scopes = scopes
+		i += end + 1
+	}
+
+	if i < len(content) && content[i] == '!' {
+		h.Breaking = true
+		i++
+	}
+
+	if !bytes.HasPrefix(content[i:], []byte(": ")) {
+		return nil, fmt.Errorf(
+			`%w: missing ": " after type and scope`, ErrInvalidHeader,
+		)
+	}
+
+	h.Description = string(bytes.TrimSpace(content[i+2:]))
+	if h.Description == "" {
+		return nil, fmt.Errorf("%w: missing description", ErrInvalidHeader)
+	}
+
+	return h, nil
+}
+
+// parsePattern parses the given line using the custom header Pattern.
+func (s *HeaderParser) parsePattern(line *Line) (*Header, error) {
+	m := s.Pattern.FindSubmatchIndex(line.Content)
+	if m == nil {
+		return nil, fmt.Errorf("%w: does not match pattern", ErrInvalidHeader)
+	}
+
+	h := &Header{
+		Scopes: []*HeaderScope{},
+		Fields: map[string]string{},
+		Line:   line,
+	}
+
+	for i, name := range s.Pattern.SubexpNames() {
+		if name == "" || m[i*2] < 0 {
+			continue
+		}
+
+		start, end := m[i*2], m[i*2+1]
+		value := string(line.Content[start:end])
+
+		switch name {
+		case "type":
+			h.Type = value
+			h.TypeSpan = &Span{Line: line, Start: start, End: end}
+		case "scope":
+			if start == end {
+				continue
+			}
+
+			scopes, err := s.parseScopes(line, start, end)
+			if err != nil {
+				return nil, err
+			}
+			h.Scopes = scopes
+		case "breaking":
+			h.Breaking = value != ""
+		case "description":
+			h.Description = strings.TrimSpace(value)
+		default:
+			h.Fields[name] = value
+		}
+	}
+
+	if h.Type == "" {
+		return nil, fmt.Errorf("%w: missing type", ErrInvalidHeader)
+	}
+	if h.Description == "" {
+		return nil, fmt.Errorf("%w: missing description", ErrInvalidHeader)
+	}
+
+	return h, nil
+}
+
+// parseScopes parses the scopes between the start and end offsets of the given
+// line.
+func (s *HeaderParser) parseScopes(
+	line *Line,
+	start int,
+	end int,
+) ([]*HeaderScope, error) {
+	delimiters := s.ScopeDelimiters
+	if delimiters == "" {
+		delimiters = ","
+	}
+	separator := s.ScopeSeparator
+	if separator == "" {
+		separator = "/"
+	}
+
+	r := []*HeaderScope{}
+
+	offset := start
+	for offset <= end {
+		next := bytes.IndexAny(line.Content[offset:end], delimiters)
+		if next < 0 {
+			next = end
+		} else {
+			next += offset
+		}
+
+		// Trim surrounding whitespace, as in "feat(api, cli): ...".
+		from, to := offset, next
+		for from < to && isSpace(line.Content[from]) {
+			from++
+		}
+		for to > from && isSpace(line.Content[to-1]) {
+			to--
+		}
+
+		name := string(line.Content[from:to])
+		if name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf(
+				"%w: invalid scope %q", ErrInvalidHeader, name,
+			)
+		}
+
+		parts := strings.Split(name, separator)
+		for _, part := range parts {
+			if part == "" {
+				return nil, fmt.Errorf(
+					"%w: invalid scope %q", ErrInvalidHeader, name,
+				)
+			}
+		}
+
+		r = append(r, &HeaderScope{
+			Name:  name,
+			Parts: parts,
+			Span:  &Span{Line: line, Start: from, End: to},
+		})
+
+		offset = next + 1
+	}
+
+	return r, nil
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
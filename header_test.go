@@ -0,0 +1,341 @@
+package conventionalcommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeaderParser_Parse(t *testing.T) {
+	type scope struct {
+		Name  string
+		Parts []string
+		Text  string
+	}
+	tests := []struct {
+		name        string
+		parser      *HeaderParser
+		header      string
+		typ         string
+		scopes      []scope
+		breaking    bool
+		description string
+		wantErr     string
+	}{
+		{
+			name:        "type only",
+			header:      "feat: add a thing",
+			typ:         "feat",
+			scopes:      []scope{},
+			description: "add a thing",
+		},
+		{
+			name:        "breaking",
+			header:      "feat!: add a thing",
+			typ:         "feat",
+			scopes:      []scope{},
+			breaking:    true,
+			description: "add a thing",
+		},
+		{
+			name:   "single scope",
+			header: "fix(api)!: a broken thing",
+			typ:    "fix",
+			scopes: []scope{
+				{Name: "api", Parts: []string{"api"}, Text: "api"},
+			},
+			breaking:    true,
+			description: "a broken thing",
+		},
+		{
+			name:   "multiple scopes",
+			header: "feat(api, cli): add a thing",
+			typ:    "feat",
+			scopes: []scope{
+				{Name: "api", Parts: []string{"api"}, Text: "api"},
+				{Name: "cli", Parts: []string{"cli"}, Text: "cli"},
+			},
+			description: "add a thing",
+		},
+		{
+			name:   "nested scopes",
+			header: "fix(api/users,cli): a broken thing",
+			typ:    "fix",
+			scopes: []scope{
+				{
+					Name:  "api/users",
+					Parts: []string{"api", "users"},
+					Text:  "api/users",
+				},
+				{Name: "cli", Parts: []string{"cli"}, Text: "cli"},
+			},
+			description: "a broken thing",
+		},
+		{
+			name: "custom delimiters and separator",
+			parser: &HeaderParser{
+				ScopeDelimiters: "|+",
+				ScopeSeparator:  ".",
+			},
+			header: "fix(api.users|cli+web/ui): a broken thing",
+			typ:    "fix",
+			scopes: []scope{
+				{
+					Name:  "api.users",
+					Parts: []string{"api", "users"},
+					Text:  "api.users",
+				},
+				{Name: "cli", Parts: []string{"cli"}, Text: "cli"},
+				{Name: "web/ui", Parts: []string{"web/ui"}, Text: "web/ui"},
+			},
+			description: "a broken thing",
+		},
+		{
+			name:    "missing type",
+			header:  "(api): a broken thing",
+			wantErr: "invalid header: missing type",
+		},
+		{
+			name:    "unclosed scope",
+			header:  "fix(api: a broken thing",
+			wantErr: "invalid header: unclosed scope",
+		},
+		{
+			name:    "empty scope",
+			header:  "fix(): a broken thing",
+			wantErr: `invalid header: invalid scope ""`,
+		},
+		{
+			name:    "empty scope in list",
+			header:  "fix(api,): a broken thing",
+			wantErr: `invalid header: invalid scope ""`,
+		},
+		{
+			name:    "empty nested scope",
+			header:  "fix(api/): a broken thing",
+			wantErr: `invalid header: invalid scope "api/"`,
+		},
+		{
+			name:    "scope with space",
+			header:  "fix(api users): a broken thing",
+			wantErr: `invalid header: invalid scope "api users"`,
+		},
+		{
+			name:    "missing separator",
+			header:  "fix a broken thing",
+			wantErr: `invalid header: missing ": " after type and scope`,
+		},
+		{
+			name:    "missing space",
+			header:  "fix:a broken thing",
+			wantErr: `invalid header: missing ": " after type and scope`,
+		},
+		{
+			name:    "missing description",
+			header:  "fix:  ",
+			wantErr: "invalid header: missing description",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := tt.parser
+			if parser == nil {
+				parser = &HeaderParser{}
+			}
+			line := &Line{Number: 1, Content: []byte(tt.header)}
+
+			got, err := parser.Parse(line)
+
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrInvalidHeader)
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.typ, got.Type)
+			assert.Equal(t, tt.breaking, got.Breaking)
+			assert.Equal(t, tt.description, got.Description)
+			assert.Same(t, line, got.Line)
+
+			scopes := []scope{}
+			for _, s := range got.Scopes {
+				scopes = append(scopes, scope{
+					Name:  s.Name,
+					Parts: s.Parts,
+					Text:  s.Span.String(),
+				})
+			}
+			assert.Equal(t, tt.scopes, scopes)
+		})
+	}
+}
+
+func TestRawMessage_Header(t *testing.T) {
+	msg := NewRawMessage([]byte("\nfeat(api): add a thing\n\nMore details."))
+
+	got, err := msg.Header()
+	require.NoError(t, err)
+	assert.Equal(t, "feat", got.Type)
+	assert.Equal(t, 2, got.Line.Number)
+
+	_, err = NewRawMessage([]byte("  \n")).Header()
+	assert.EqualError(t, err, "invalid header: message is empty")
+}
+
+func TestCompileHeaderPattern(t *testing.T) {
+	tests := []struct {
+		name    string
+		expr    string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			expr: `^(?P<type>\w+): (?P<description>.+)$`,
+		},
+		{
+			name: "invalid expression",
+			expr: `^(?P<type>\w+`,
+			wantErr: "error parsing regexp: missing closing ): " +
+				"`^(?P<type>\\w+`",
+		},
+		{
+			name:    "missing type",
+			expr:    `^(?P<kind>\w+): (?P<description>.+)$`,
+			wantErr: `header pattern is missing a named "type" group`,
+		},
+		{
+			name:    "missing description",
+			expr:    `^(?P<type>\w+): (?P<desc>.+)$`,
+			wantErr: `header pattern is missing a named "description" group`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CompileHeaderPattern(tt.expr)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expr, got.String())
+		})
+	}
+}
+
+func TestHeaderParser_Parse_Pattern(t *testing.T) {
+	typ := `(?P<type>\w+)`
+	scope := `(?:\((?P<scope>[^()]*)\))?(?P<breaking>!)?`
+	ticket := `(?P<ticket>[A-Z]+-\d+)`
+	desc := `(?P<description>.+)$`
+	tests := []struct {
+		name        string
+		pattern     string
+		header      string
+		typ         string
+		scopes      []string
+		breaking    bool
+		description string
+		fields      map[string]string
+		wantErr     string
+	}{
+		{
+			name:        "ticket prefix",
+			pattern:     `^\[` + ticket + `\] ` + typ + scope + `: ` + desc,
+			header:      "[PROJ-123] feat(api,cli)!: add a thing",
+			typ:         "feat",
+			scopes:      []string{"api", "cli"},
+			breaking:    true,
+			description: "add a thing",
+			fields:      map[string]string{"ticket": "PROJ-123"},
+		},
+		{
+			name:        "ticket in description",
+			pattern:     `^` + typ + scope + `: ` + ticket + ` ` + desc,
+			header:      "fix(api/users): PROJ-123 a broken thing",
+			typ:         "fix",
+			scopes:      []string{"api/users"},
+			description: "a broken thing",
+			fields:      map[string]string{"ticket": "PROJ-123"},
+		},
+		{
+			name:        "gitmoji prefix",
+			pattern:     `^(?P<emoji>:\w+:) ` + typ + scope + `: ` + desc,
+			header:      ":sparkles: feat: add a thing",
+			typ:         "feat",
+			scopes:      []string{},
+			description: "add a thing",
+			fields:      map[string]string{"emoji": ":sparkles:"},
+		},
+		{
+			name:        "optional field",
+			pattern:     `^` + typ + `: (?:` + ticket + ` )?` + desc,
+			header:      "fix: a broken thing",
+			typ:         "fix",
+			scopes:      []string{},
+			description: "a broken thing",
+			fields:      map[string]string{},
+		},
+		{
+			name:    "no match",
+			pattern: `^\[` + ticket + `\] ` + typ + `: ` + desc,
+			header:  "feat: add a thing",
+			wantErr: "invalid header: does not match pattern",
+		},
+		{
+			name:    "invalid scope",
+			pattern: `^` + typ + scope + `: ` + desc,
+			header:  "fix(api,): a broken thing",
+			wantErr: `invalid header: invalid scope ""`,
+		},
+		{
+			name:    "empty type",
+			pattern: `^(?P<type>\w*): (?P<description>.*)$`,
+			header:  ": a broken thing",
+			wantErr: "invalid header: missing type",
+		},
+		{
+			name:    "empty description",
+			pattern: `^(?P<type>\w*): (?P<description>.*)$`,
+			header:  "fix: ",
+			wantErr: "invalid header: missing description",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			re, err := CompileHeaderPattern(tt.pattern)
+			require.NoError(t, err)
+			parser := &HeaderParser{Pattern: re}
+			line := &Line{Number: 1, Content: []byte(tt.header)}
+
+			got, err := parser.Parse(line)
+
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, ErrInvalidHeader)
+				assert.EqualError(t, err, tt.wantErr)
+				assert.Nil(t, got)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.typ, got.Type)
+			assert.Equal(t, tt.breaking, got.Breaking)
+			assert.Equal(t, tt.description, got.Description)
+			assert.Equal(t, tt.fields, got.Fields)
+
+			scopes := []string{}
+			for _, s := range got.Scopes {
+				scopes = append(scopes, s.Span.String())
+			}
+			assert.Equal(t, tt.scopes, scopes)
+		})
+	}
+}
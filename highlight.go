@@ -0,0 +1,316 @@
+package conventionalcommit
+
+import (
+	"bytes"
+	"sort"
+	"unicode"
+)
+
+// ansiReset is the ANSI escape sequence which resets all text attributes.
+const ansiReset = "\x1b[0m"
+
+// Theme defines the ANSI escape sequences used to colorize each part of a
+// message. Parts with an empty sequence are not colorized.
+type Theme struct {
+	// Type is used for the commit type.
+	Type string
+
+	// Scope is used for each scope within the parentheses.
+	Scope string
+
+	// Breaking is used for the "!" breaking change marker.
+	Breaking string
+
+	// Description is used for the header description.
+	Description string
+
+	// TrailerToken is used for the token of each trailer.
+	TrailerToken string
+
+	// TrailerValue is used for the value of each trailer.
+	TrailerValue string
+
+	// Diagnostic is used for underlines and their messages.
+	Diagnostic string
+}
+
+// DefaultTheme returns a Theme using the basic ANSI colors, which work in
+// nearly all terminals.
+func DefaultTheme() *Theme {
+	return &Theme{
+		Type:         "\x1b[1;34m",
+		Scope:        "\x1b[36m",
+		Breaking:     "\x1b[1;31m",
+		Description:  "\x1b[1m",
+		TrailerToken: "\x1b[33m",
+		TrailerValue: "\x1b[32m",
+		Diagnostic:   "\x1b[31m",
+	}
+}
+
+// highlight is a colorized range of bytes within a single line.
+type highlight struct {
+	start int
+	end   int
+	color string
+}
+
+// Highlight returns the message colorized with the given theme, or not
+// colorized at all if theme is nil. The header is parsed with the given
+// HeaderParser, or the default HeaderParser if nil, and is not colorized if it
+// is not a valid header.
+//
+// Each of the given diagnostics is shown like a compiler error, with its span
+// underlined beneath the line it is on, followed by its message. Underlines are
+// aligned using the display width of each character in a terminal, with tabs
+// copied from the line. Nil diagnostics, and diagnostics with spans which are
+// not within a line of the message, are ignored.
+func Highlight(
+	msg *RawMessage,
+	parser *HeaderParser,
+	theme *Theme,
+	diagnostics ...*Diagnostic,
+) []byte {
+	highlights := map[int][]*highlight{}
+	add := func(line *Line, start, end int, color string) {
+		if color == "" || start >= end {
+			return
+		}
+		highlights[line.Number] = append(
+			highlights[line.Number],
+			&highlight{start: start, end: end, color: color},
+		)
+	}
+
+	if parser == nil {
+		parser = &HeaderParser{}
+	}
+	if theme == nil {
+		theme = &Theme{}
+	}
+	if len(msg.Paragraphs) > 0 {
+		h, err := parser.Parse(msg.Paragraphs[0].Lines[0])
+		if err == nil {
+			highlightHeader(h, theme, add)
+		}
+	}
+
+	for _, t := range msg.Footers() {
+		first := t.Lines[0]
+		token := len(t.Token)
+		add(first, 0, token, theme.TrailerToken)
+
+		value := token + bytes.Index(first.Content[token:], []byte(t.Separator))
+		if t.Separator != "#" {
+			value += len(t.Separator)
+		}
+		for i, l := range t.Lines {
+			start := 0
+			if i == 0 {
+				start = value
+			}
+			for start < len(l.Content) && isSpace(l.Content[start]) {
+				start++
+			}
+			add(l, start, len(l.Content), theme.TrailerValue)
+		}
+	}
+
+	lineDiagnostics := map[int][]*Diagnostic{}
+	for _, d := range diagnostics {
+		if d == nil || !msg.validSpan(d.Span) {
+			continue
+		}
+
+		n := d.Span.Line.Number
+		lineDiagnostics[n] = append(lineDiagnostics[n], d)
+	}
+
+	lb := msg.lineBreak()
+	b := []byte{}
+	for _, l := range msg.Lines {
+		b = appendHighlighted(b, l.Content, highlights[l.Number])
+
+		ds := lineDiagnostics[l.Number]
+		sort.SliceStable(ds, func(i, j int) bool {
+			return ds[i].Span.Start < ds[j].Span.Start
+		})
+		for _, d := range ds {
+			b = append(b, lb...)
+			b = appendUnderline(b, d, theme.Diagnostic)
+		}
+
+		b = append(b, l.Break...)
+	}
+
+	return b
+}
+
+// highlightHeader calls add for each part of the given header.
+func highlightHeader(
+	h *Header,
+	theme *Theme,
+	add func(line *Line, start, end int, color string),
+) {
+	content := h.Line.Content
+
+	if h.TypeSpan != nil {
+		add(h.Line, h.TypeSpan.Start, h.TypeSpan.End, theme.Type)
+	}
+	for _, scope := range h.Scopes {
+		add(h.Line, scope.Span.Start, scope.Span.End, theme.Scope)
+	}
+
+	if h.Breaking && h.TypeSpan != nil {
+		pos := h.TypeSpan.End
+		if len(h.Scopes) > 0 {
+			pos = h.Scopes[len(h.Scopes)-1].Span.End
+			pos += bytes.IndexByte(content[pos:], ')') + 1
+		}
+		if pos < len(content) && content[pos] == '!' {
+			add(h.Line, pos, pos+1, theme.Breaking)
+		}
+	}
+
+	if h.Description != "" {
+		start := bytes.LastIndex(content, []byte(h.Description))
+		if start >= 0 {
+			add(h.Line, start, start+len(h.Description), theme.Description)
+		}
+	}
+}
+
+// appendHighlighted appends content to b, wrapping each of the given
+// highlights in its color and a reset sequence.
+func appendHighlighted(b []byte, content []byte, hs []*highlight) []byte {
+	sort.SliceStable(hs, func(i, j int) bool {
+		return hs[i].start < hs[j].start
+	})
+
+	pos := 0
+	for _, h := range hs {
+		if h.start < pos {
+			continue
+		}
+		b = append(b, content[pos:h.start]...)
+		b = append(b, h.color...)
+		b = append(b, content[h.start:h.end]...)
+		b = append(b, ansiReset...)
+		pos = h.end
+	}
+
+	return append(b, content[pos:]...)
+}
+
+// appendUnderline appends a line to b which underlines the span of the given
+// diagnostic with carets, followed by the diagnostic's message.
+func appendUnderline(b []byte, d *Diagnostic, color string) []byte {
+	content := d.Span.Line.Content
+
+	for _, r := range string(content[:d.Span.Start]) {
+		if r == '\t' {
+			b = append(b, '\t')
+
+			continue
+		}
+		b = append(b, bytes.Repeat([]byte{' '}, runeWidth(r))...)
+	}
+	if color != "" {
+		b = append(b, color...)
+	}
+
+	width := 0
+	for _, r := range string(content[d.Span.Start:d.Span.End]) {
+		width += runeWidth(r)
+	}
+	if width == 0 {
+		width = 1
+	}
+	b = append(b, bytes.Repeat([]byte{'^'}, width)...)
+
+	if d.Message != "" {
+		b = append(b, ' ')
+		b = append(b, d.Message...)
+	}
+	if color != "" {
+		b = append(b, ansiReset...)
+	}
+
+	return b
+}
+
+// runeWidth returns the number of columns the given rune occupies in a
+// terminal. Combining marks, including emoji variation selectors, and format
+// characters like the zero width joiner occupy none, while wide East Asian
+// characters and emoji occupy two. Tabs count as a single column.
+func runeWidth(r rune) int {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case unicode.Is(wideRunes, r):
+		return 2
+	}
+
+	return 1
+}
+
+// wideRunes are the characters which occupy two columns in a terminal; East
+// Asian wide and fullwidth characters, and emoji which are displayed as emoji
+// by default, like the ones used by gitmoji.
+var wideRunes = &unicode.RangeTable{
+	R16: []unicode.Range16{
+		{Lo: 0x1100, Hi: 0x115f, Stride: 1},
+		{Lo: 0x231a, Hi: 0x231b, Stride: 1},
+		{Lo: 0x2329, Hi: 0x232a, Stride: 1},
+		{Lo: 0x23e9, Hi: 0x23ec, Stride: 1},
+		{Lo: 0x23f0, Hi: 0x23f3, Stride: 3},
+		{Lo: 0x25fd, Hi: 0x25fe, Stride: 1},
+		{Lo: 0x2614, Hi: 0x2615, Stride: 1},
+		{Lo: 0x2648, Hi: 0x2653, Stride: 1},
+		{Lo: 0x267f, Hi: 0x2693, Stride: 20},
+		{Lo: 0x26a1, Hi: 0x26a1, Stride: 1},
+		{Lo: 0x26aa, Hi: 0x26ab, Stride: 1},
+		{Lo: 0x26bd, Hi: 0x26be, Stride: 1},
+		{Lo: 0x26c4, Hi: 0x26c5, Stride: 1},
+		{Lo: 0x26ce, Hi: 0x26d4, Stride: 6},
+		{Lo: 0x26ea, Hi: 0x26ea, Stride: 1},
+		{Lo: 0x26f2, Hi: 0x26f3, Stride: 1},
+		{Lo: 0x26f5, Hi: 0x26fa, Stride: 5},
+		{Lo: 0x26fd, Hi: 0x2705, Stride: 8},
+		{Lo: 0x270a, Hi: 0x270b, Stride: 1},
+		{Lo: 0x2728, Hi: 0x274c, Stride: 36},
+		{Lo: 0x274e, Hi: 0x274e, Stride: 1},
+		{Lo: 0x2753, Hi: 0x2755, Stride: 1},
+		{Lo: 0x2757, Hi: 0x2757, Stride: 1},
+		{Lo: 0x2795, Hi: 0x2797, Stride: 1},
+		{Lo: 0x27b0, Hi: 0x27bf, Stride: 15},
+		{Lo: 0x2b1b, Hi: 0x2b1c, Stride: 1},
+		{Lo: 0x2b50, Hi: 0x2b55, Stride: 5},
+		{Lo: 0x2e80, Hi: 0x303e, Stride: 1},
+		{Lo: 0x3041, Hi: 0x33ff, Stride: 1},
+		{Lo: 0x3400, Hi: 0x4dbf, Stride: 1},
+		{Lo: 0x4e00, Hi: 0xa4cf, Stride: 1},
+		{Lo: 0xa960, Hi: 0xa97f, Stride: 1},
+		{Lo: 0xac00, Hi: 0xd7a3, Stride: 1},
+		{Lo: 0xf900, Hi: 0xfaff, Stride: 1},
+		{Lo: 0xfe10, Hi: 0xfe19, Stride: 1},
+		{Lo: 0xfe30, Hi: 0xfe6f, Stride: 1},
+		{Lo: 0xff00, Hi: 0xff60, Stride: 1},
+		{Lo: 0xffe0, Hi: 0xffe6, Stride: 1},
+	},
+	R32: []unicode.Range32{
+		{Lo: 0x16fe0, Hi: 0x18cff, Stride: 1},
+		{Lo: 0x1b000, Hi: 0x1b2ff, Stride: 1},
+		{Lo: 0x1f004, Hi: 0x1f004, Stride: 1},
+		{Lo: 0x1f0cf, Hi: 0x1f0cf, Stride: 1},
+		{Lo: 0x1f18e, Hi: 0x1f18e, Stride: 1},
+		{Lo: 0x1f191, Hi: 0x1f19a, Stride: 1},
+		{Lo: 0x1f200, Hi: 0x1f251, Stride: 1},
+		{Lo: 0x1f300, Hi: 0x1f64f, Stride: 1},
+		{Lo: 0x1f680, Hi: 0x1f6ff, Stride: 1},
+		{Lo: 0x1f7e0, Hi: 0x1f7eb, Stride: 1},
+		{Lo: 0x1f90c, Hi: 0x1f9ff, Stride: 1},
+		{Lo: 0x1fa70, Hi: 0x1faff, Stride: 1},
+		{Lo: 0x20000, Hi: 0x3fffd, Stride: 1},
+	},
+}
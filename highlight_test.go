@@ -0,0 +1,182 @@
+package conventionalcommit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHighlight(t *testing.T) {
+	theme := &Theme{
+		Type:         "<t>",
+		Scope:        "<s>",
+		Breaking:     "<b>",
+		Description:  "<d>",
+		TrailerToken: "<k>",
+		TrailerValue: "<v>",
+		Diagnostic:   "<e>",
+	}
+	type diagnostic struct {
+		line    int
+		start   int
+		end     int
+		message string
+	}
+	pattern, err := CompileHeaderPattern(
+		`^\[(?P<ticket>[A-Z]+-\d+)\] (?P<type>\w+)(?P<breaking>!)?: ` +
+			`(?P<description>.+)$`,
+	)
+	require.NoError(t, err)
+	tests := []struct {
+		name        string
+		parser      *HeaderParser
+		theme       *Theme
+		message     string
+		diagnostics []diagnostic
+		want        string
+	}{
+		{
+			name:    "header",
+			message: "feat(api, cli)!: add a thing",
+			want: "<t>feat</>(<s>api</>, <s>cli</>)<b>!</>: " +
+				"<d>add a thing</>",
+		},
+		{
+			name:    "breaking without scope",
+			message: "feat!: add a thing",
+			want:    "<t>feat</><b>!</>: <d>add a thing</>",
+		},
+		{
+			name:    "gitmoji",
+			parser:  &HeaderParser{Gitmoji: DefaultGitmojiTable()},
+			message: "🐛 fix(api): a broken thing",
+			want:    "🐛 <t>fix</>(<s>api</>): <d>a broken thing</>",
+		},
+		{
+			name:    "type from gitmoji",
+			parser:  &HeaderParser{Gitmoji: DefaultGitmojiTable()},
+			message: "✨ add a thing",
+			want:    "✨ <d>add a thing</>",
+		},
+		{
+			name:    "pattern",
+			parser:  &HeaderParser{Pattern: pattern},
+			message: "[PROJ-12] feat!: add a thing",
+			want:    "[PROJ-12] <t>feat</><b>!</>: <d>add a thing</>",
+		},
+		{
+			name:    "invalid header",
+			message: "Add a thing\n\nMore details.",
+			want:    "Add a thing\n\nMore details.",
+		},
+		{
+			name: "trailers",
+			message: "fix: a broken thing\n\nMore details.\n\n" +
+				"Refs: #12\nFixes #34\nSigned-off-by: John Smith\n" +
+				"  <john@example.com>\n",
+			want: "<t>fix</>: <d>a broken thing</>\n\nMore details.\n\n" +
+				"<k>Refs</>: <v>#12</>\n<k>Fixes</> <v>#34</>\n" +
+				"<k>Signed-off-by</>: <v>John Smith</>\n" +
+				"  <v><john@example.com></>\n",
+		},
+		{
+			name:    "empty theme",
+			theme:   &Theme{},
+			message: "feat(api)!: add a thing\n\nRefs: #12",
+			diagnostics: []diagnostic{
+				{line: 1, start: 0, end: 4, message: "x"},
+			},
+			want: "feat(api)!: add a thing\n^^^^ x\n\n" +
+				"Refs: #12",
+		},
+		{
+			name:    "diagnostics",
+			message: "feta(ÄPI): add a thing\r\n\r\nMore details",
+			diagnostics: []diagnostic{
+				{line: 3, start: 12, end: 12, message: "missing period"},
+				{line: 1, start: 5, end: 9, message: "unknown scope"},
+				{line: 1, start: 0, end: 4, message: "unknown type"},
+				{line: 3, start: 12, end: 20, message: "out of range"},
+			},
+			want: "<t>feta</>(<s>ÄPI</>): <d>add a thing</>\r\n" +
+				"<e>^^^^ unknown type</>\r\n" +
+				"     <e>^^^ unknown scope</>\r\n" +
+				"\r\n" +
+				"More details\r\n" +
+				"            <e>^ missing period</>",
+		},
+		{
+			name:    "diagnostics after wide characters and tabs",
+			parser:  &HeaderParser{Gitmoji: DefaultGitmojiTable()},
+			message: "✨ feat: add 日本\n\n\tMore\tdetails",
+			diagnostics: []diagnostic{
+				{line: 1, start: 4, end: 8, message: "a"},
+				{line: 1, start: 14, end: 20, message: "b"},
+				{line: 3, start: 6, end: 13, message: "c"},
+			},
+			want: "✨ <t>feat</>: <d>add 日本</>\n" +
+				"   <e>^^^^ a</>\n" +
+				"             <e>^^^^ b</>\n" +
+				"\n" +
+				"\tMore\tdetails\n" +
+				"\t    \t<e>^^^^^^^ c</>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.theme == nil {
+				tt.theme = theme
+			}
+			msg := NewRawMessage([]byte(tt.message))
+			diagnostics := []*Diagnostic{}
+			for _, d := range tt.diagnostics {
+				diagnostics = append(diagnostics, &Diagnostic{
+					Span: &Span{
+						Line:  msg.Lines[d.line-1],
+						Start: d.start,
+						End:   d.end,
+					},
+					Message: d.message,
+				})
+			}
+
+			got := Highlight(msg, tt.parser, tt.theme, diagnostics...)
+
+			assert.Equal(t,
+				tt.want, strings.ReplaceAll(string(got), ansiReset, "</>"),
+			)
+		})
+	}
+}
+
+func TestHighlight_Nil(t *testing.T) {
+	msg := NewRawMessage([]byte("feat: add a thing."))
+
+	got := Highlight(msg, nil, nil, nil, &Diagnostic{
+		Span:    &Span{Line: msg.Lines[0], Start: 17, End: 18},
+		Message: "x",
+	})
+
+	assert.Equal(t,
+		"feat: add a thing.\n                 ^ x", string(got),
+	)
+}
+
+func TestHighlight_FixDiagnostics(t *testing.T) {
+	msg := NewRawMessage([]byte("Feat: add a thing."))
+	h, err := msg.Header()
+	require.NoError(t, err)
+
+	got := Highlight(msg, nil, &Theme{},
+		h.TypeCaseFix().Diagnostic(), h.TrailingPeriodFix().Diagnostic(),
+	)
+
+	assert.Equal(t,
+		"Feat: add a thing.\n"+
+			"^^^^ type \"Feat\" must be lowercase\n"+
+			"                 ^ description must not end with a period",
+		string(got),
+	)
+}
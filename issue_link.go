@@ -0,0 +1,107 @@
+package conventionalcommit
+
+import "strings"
+
+// IssueLinker renders links to issues for extracted issue references, based on
+// a URL template for each kind of tracker.
+//
+// Templates may contain the following placeholders, which are replaced with
+// the corresponding field of the IssueReference:
+//
+//	{host}   Host
+//	{owner}  Owner
+//	{repo}   Repo
+//	{id}     ID
+//	{key}    ID, as Jira templates typically refer to issue keys
+//	{kind}   Kind, as used in GitHub and GitLab URLs; "issues", "pull", or
+//	         "merge_requests"
+//
+// For example "https://jira.example.com/browse/{key}", or
+// "https://gitlab.com/{owner}/{repo}/-/{kind}/{id}".
+type IssueLinker struct {
+	// Templates maps trackers to URL templates.
+	Templates map[Tracker]string
+
+	// Host, Owner and Repo are used in place of the corresponding fields of
+	// references which do not specify them, like "#12".
+	Host  string
+	Owner string
+	Repo  string
+}
+
+// issueKindPaths are the URL path segments GitHub and GitLab use for each
+// kind of item.
+var issueKindPaths = map[IssueKind]string{
+	IssueKindIssue:        "issues",
+	IssueKindPullRequest:  "pull",
+	IssueKindMergeRequest: "merge_requests",
+}
+
+// URL returns the link for the given issue reference. If no template is
+// configured for the reference's tracker, references which were written as a
+// URL are returned as is. The same goes for references to pull and merge
+// requests when the template does not use the {kind} placeholder, as it links
+// to issues. The second return value is false when no link can be rendered,
+// either because there is no template, or because the template uses a
+// placeholder which has no value.
+func (s *IssueLinker) URL(ref *IssueReference) (string, bool) {
+	tmpl, ok := s.Templates[ref.Tracker]
+	if ok && ref.Kind != "" && ref.Kind != IssueKindIssue &&
+		!strings.Contains(tmpl, "{kind}") {
+		ok = false
+	}
+	if !ok {
+		if ref.Host != "" && ref.Span != nil {
+			return ref.Span.String(), true
+		}
+
+		return "", false
+	}
+
+	values := map[string]string{
+		"host":  ref.Host,
+		"owner": ref.Owner,
+		"repo":  ref.Repo,
+		"id":    ref.ID,
+		"key":   ref.ID,
+		"kind":  issueKindPaths[ref.Kind],
+	}
+	if ref.Owner == "" {
+		values["owner"] = s.Owner
+		values["repo"] = s.Repo
+	}
+	if ref.Host == "" {
+		values["host"] = s.Host
+	}
+
+	var b strings.Builder
+	for {
+		i := strings.IndexByte(tmpl, '{')
+		if i < 0 {
+			break
+		}
+
+		j := strings.IndexByte(tmpl[i:], '}')
+		if j < 0 {
+			break
+		}
+
+		v, ok := values[tmpl[i+1:i+j]]
+		if !ok {
+			b.WriteString(tmpl[:i+j+1])
+			tmpl = tmpl[i+j+1:]
+
+			continue
+		}
+		if v == "" {
+			return "", false
+		}
+
+		b.WriteString(tmpl[:i])
+		b.WriteString(v)
+		tmpl = tmpl[i+j+1:]
+	}
+	b.WriteString(tmpl)
+
+	return b.String(), true
+}
@@ -0,0 +1,128 @@
+package conventionalcommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueLinker_URL(t *testing.T) {
+	linker := &IssueLinker{
+		Templates: map[Tracker]string{
+			TrackerRepository: "https://{host}/{owner}/{repo}/issues/{id}",
+			TrackerJira:       "https://jira.example.com/browse/{key}",
+			TrackerGitLab:     "https://{host}/{owner}/{repo}/-/issues/{id}",
+		},
+		Host:  "github.example.com",
+		Owner: "org",
+		Repo:  "repo",
+	}
+
+	tests := []struct {
+		name    string
+		linker  *IssueLinker
+		message string
+		want    []string
+	}{
+		{
+			name:    "repository reference",
+			linker:  linker,
+			message: "fix: a broken thing\n\nCloses #12",
+			want:    []string{"https://github.example.com/org/repo/issues/12"},
+		},
+		{
+			name:    "cross repository reference",
+			linker:  linker,
+			message: "fix: a broken thing\n\nCloses other/thing#34",
+			want: []string{
+				"https://github.example.com/other/thing/issues/34",
+			},
+		},
+		{
+			name:    "jira key",
+			linker:  linker,
+			message: "fix: a broken thing\n\nRefs: PROJ-56",
+			want:    []string{"https://jira.example.com/browse/PROJ-56"},
+		},
+		{
+			name:   "gitlab URL",
+			linker: linker,
+			message: "fix: a broken thing\n\n" +
+				"Refs: https://gitlab.com/group/sub/project/-/issues/7",
+			want: []string{"https://gitlab.com/group/sub/project/-/issues/7"},
+		},
+		{
+			name: "kind placeholder",
+			linker: &IssueLinker{
+				Templates: map[Tracker]string{
+					TrackerGitLab: "https://git.example.com/{owner}/{repo}" +
+						"/-/{kind}/{id}",
+				},
+			},
+			message: "fix: a broken thing\n\n" +
+				"Refs: https://gitlab.com/g/p/-/merge_requests/9\n" +
+				"Refs: https://gitlab.com/g/p/-/issues/9",
+			want: []string{
+				"https://git.example.com/g/p/-/merge_requests/9",
+				"https://git.example.com/g/p/-/issues/9",
+			},
+		},
+		{
+			name:   "merge request without kind placeholder",
+			linker: linker,
+			message: "fix: a broken thing\n\n" +
+				"Refs: https://gitlab.com/g/p/-/merge_requests/9",
+			want: []string{"https://gitlab.com/g/p/-/merge_requests/9"},
+		},
+		{
+			name:   "URL without template",
+			linker: linker,
+			message: "fix: a broken thing\n\n" +
+				"Refs: https://github.com/o/r/pull/8",
+			want: []string{"https://github.com/o/r/pull/8"},
+		},
+		{
+			name: "unknown placeholders are retained",
+			linker: &IssueLinker{
+				Templates: map[Tracker]string{
+					TrackerJira: "https://jira.example.com/browse/{key}?x={y}",
+				},
+			},
+			message: "fix: a broken thing\n\nRefs: PROJ-56",
+			want: []string{
+				"https://jira.example.com/browse/PROJ-56?x={y}",
+			},
+		},
+		{
+			name: "missing placeholder value",
+			linker: &IssueLinker{
+				Templates: map[Tracker]string{
+					TrackerRepository: "https://github.com/{owner}/{repo}" +
+						"/issues/{id}",
+				},
+			},
+			message: "fix: a broken thing\n\nCloses #12",
+			want:    []string{""},
+		},
+		{
+			name:    "no template",
+			linker:  &IssueLinker{},
+			message: "fix: a broken thing\n\nCloses #12",
+			want:    []string{""},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewRawMessage([]byte(tt.message))
+
+			got := []string{}
+			for _, ref := range msg.IssueReferences() {
+				url, ok := tt.linker.URL(ref)
+				assert.Equal(t, url != "", ok)
+				got = append(got, url)
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
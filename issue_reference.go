@@ -0,0 +1,343 @@
+package conventionalcommit
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// IssueAction describes what a commit does to the issue it references.
+type IssueAction string
+
+const (
+	// IssueActionRefs is used for plain references to an issue, for example
+	// "Refs: #12", or "See #12".
+	IssueActionRefs IssueAction = "refs"
+
+	// IssueActionCloses is used for references which close the issue, for
+	// example "Closes #12", or "Fixes #12".
+	IssueActionCloses IssueAction = "closes"
+)
+
+// Tracker identifies the kind of issue tracker a reference points to.
+type Tracker string
+
+const (
+	// TrackerRepository is used for "#12" and "owner/repo#12" references,
+	// which point to the issue tracker of the repository hosting the
+	// commit, without saying which kind of tracker that is.
+	TrackerRepository Tracker = "repository"
+
+	// TrackerGitHub is used for GitHub issue and pull request URLs.
+	TrackerGitHub Tracker = "github"
+
+	// TrackerGitLab is used for GitLab issue and merge request URLs.
+	TrackerGitLab Tracker = "gitlab"
+
+	// TrackerJira is used for Jira issue keys like "PROJ-123", and Jira
+	// issue URLs.
+	TrackerJira Tracker = "jira"
+)
+
+// IssueKind identifies what kind of item an issue reference points to.
+type IssueKind string
+
+const (
+	// IssueKindIssue is used for references to issues. References like "#12"
+	// are assumed to point to an issue, as GitHub redirects issue URLs to pull
+	// requests with the same number.
+	IssueKindIssue IssueKind = "issue"
+
+	// IssueKindPullRequest is used for GitHub pull request URLs.
+	IssueKindPullRequest IssueKind = "pull_request"
+
+	// IssueKindMergeRequest is used for GitLab merge request URLs. GitLab
+	// numbers merge requests separately from issues.
+	IssueKindMergeRequest IssueKind = "merge_request"
+)
+
+// IssueReference represents a single reference to an issue found in the body
+// or footers of a commit message.
+type IssueReference struct {
+	// Action is what the commit does to the referenced issue.
+	Action IssueAction
+
+	// Tracker is the kind of issue tracker the issue lives in.
+	Tracker Tracker
+
+	// Kind is the kind of item referenced.
+	Kind IssueKind
+
+	// Host is the hostname of the tracker, only set for URL references.
+	Host string
+
+	// Owner is the user, organization or (sub-)group the repository belongs
+	// to. Only set for "owner/repo#12" and URL references to GitHub and
+	// GitLab.
+	Owner string
+
+	// Repo is the name of the repository. Only set when Owner is set.
+	Repo string
+
+	// ID is the issue number for GitHub, GitLab and repository references,
+	// and the full issue key (for example "PROJ-123") for Jira references.
+	ID string
+
+	// Span is the location of the reference within the commit message.
+	Span *Span
+}
+
+var issueReferenceRegexp = regexp.MustCompile(
+	`https?://(?P<jirahost>[\w.:-]+)/(?:[\w.-]+/)*browse/` +
+		`(?P<jiraurlkey>[A-Z][A-Z0-9_]+-\d+)\b` +
+		`|https?://(?P<host>[\w.:-]+)/(?P<path>[\w.-]+(?:/[\w.-]+)+?)/` +
+		`(?P<gitlab>-/)?(?P<kind>issues|pull|merge_requests)/(?P<urlid>\d+)\b` +
+		`|\b(?P<owner>[\w.-]+(?:/[\w.-]+)*)/(?P<repo>[\w.-]+)` +
+		`#(?P<repoid>\d+)\b` +
+		`|#(?P<id>\d+)\b` +
+		`|\b(?P<jirakey>[A-Z][A-Z0-9_]+-\d+)\b`,
+)
+
+var issueActionKeywords = map[string]IssueAction{
+	"close":      IssueActionCloses,
+	"closes":     IssueActionCloses,
+	"closed":     IssueActionCloses,
+	"closing":    IssueActionCloses,
+	"fix":        IssueActionCloses,
+	"fixes":      IssueActionCloses,
+	"fixed":      IssueActionCloses,
+	"fixing":     IssueActionCloses,
+	"resolve":    IssueActionCloses,
+	"resolves":   IssueActionCloses,
+	"resolved":   IssueActionCloses,
+	"resolving":  IssueActionCloses,
+	"implement":  IssueActionCloses,
+	"implements": IssueActionCloses,
+	"ref":        IssueActionRefs,
+	"refs":       IssueActionRefs,
+	"reference":  IssueActionRefs,
+	"references": IssueActionRefs,
+	"see":        IssueActionRefs,
+	"related":    IssueActionRefs,
+	"relates":    IssueActionRefs,
+	"related-to": IssueActionRefs,
+	"relates-to": IssueActionRefs,
+	"issue":      IssueActionRefs,
+	"issues":     IssueActionRefs,
+	"part-of":    IssueActionRefs,
+	"see-also":   IssueActionRefs,
+}
+
+// IssueReferenceParser extracts issue references from commit messages. The
+// zero value only extracts Jira keys like "PROJ-123" from footers whose token
+// is a keyword like "Closes" or "Refs", as things like "UTF-8" and "SHA-256"
+// look like Jira keys too.
+type IssueReferenceParser struct {
+	// JiraProjects are the keys of Jira projects, like "PROJ", whose issue
+	// keys are also extracted from the body and from other footers.
+	JiraProjects []string
+}
+
+// IssueReferences returns all issue references found in the body and footers
+// of the message, using the default IssueReferenceParser.
+func (s *RawMessage) IssueReferences() []*IssueReference {
+	return (&IssueReferenceParser{}).IssueReferences(s)
+}
+
+// IssueReferences returns all issue references found in the body and footers
+// of the message. The first paragraph (the header) is not searched.
+//
+// Within footers whose token is a keyword like "Closes" or "Refs", every
+// reference is extracted, with the action determined by the token. Elsewhere,
+// including other footers like "BREAKING CHANGE", references are extracted
+// with the action of a preceding keyword, and Jira keys are only extracted if
+// their project is one of JiraProjects.
+func (s *IssueReferenceParser) IssueReferences(
+	msg *RawMessage,
+) []*IssueReference {
+	r := []*IssueReference{}
+
+	if len(msg.Paragraphs) < 2 {
+		return r
+	}
+
+	footers := map[*Line]IssueAction{}
+	for _, t := range msg.Footers() {
+		action, ok := issueActionKeywords[strings.ToLower(t.Token)]
+		if !ok {
+			continue
+		}
+
+		for _, l := range t.Lines {
+			footers[l] = action
+		}
+	}
+
+	for _, p := range msg.Paragraphs[1:] {
+		for _, line := range p.Lines {
+			r = append(r, s.references(line, footers[line])...)
+		}
+	}
+
+	return r
+}
+
+// references extracts all issue references from a single line. When the line
+// is part of a keyword footer, footerAction is the action implied by the
+// footer's token, otherwise it is empty.
+func (s *IssueReferenceParser) references(
+	line *Line,
+	footerAction IssueAction,
+) []*IssueReference {
+	r := []*IssueReference{}
+
+	var action IssueAction
+	offset := 0
+	for i, m := range issueReferenceRegexp.FindAllSubmatchIndex(
+		line.Content, -1,
+	) {
+		gap := line.Content[offset:m[0]]
+		offset = m[1]
+
+		if a, ok := issueActionKeyword(gap); ok {
+			action = a
+		} else if i == 0 || !isIssueListSeparator(gap) {
+			action = footerAction
+		}
+
+		// Skip matches which are part of a larger word or path.
+		if m[0] > 0 && isIssuePrefixByte(line.Content[m[0]-1]) {
+			continue
+		}
+
+		ref := newIssueReference(line.Content, m)
+		if ref.Tracker == TrackerJira && ref.Host == "" &&
+			footerAction == "" && !s.isJiraProject(ref.ID) {
+			continue
+		}
+
+		ref.Action = action
+		if ref.Action == "" {
+			ref.Action = IssueActionRefs
+		}
+		ref.Span = &Span{Line: line, Start: m[0], End: m[1]}
+
+		r = append(r, ref)
+	}
+
+	return r
+}
+
+// isJiraProject reports if the given Jira issue key belongs to one of
+// JiraProjects.
+func (s *IssueReferenceParser) isJiraProject(key string) bool {
+	project := key[:strings.LastIndexByte(key, '-')]
+	for _, p := range s.JiraProjects {
+		if p == project {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newIssueReference builds an IssueReference from the submatch indexes of a
+// single issueReferenceRegexp match.
+func newIssueReference(content []byte, m []int) *IssueReference {
+	group := func(name string) string {
+		i := issueReferenceRegexp.SubexpIndex(name)
+		if m[i*2] < 0 {
+			return ""
+		}
+
+		return string(content[m[i*2]:m[i*2+1]])
+	}
+
+	switch {
+	case group("jiraurlkey") != "":
+		return &IssueReference{
+			Tracker: TrackerJira,
+			Kind:    IssueKindIssue,
+			Host:    group("jirahost"),
+			ID:      group("jiraurlkey"),
+		}
+	case group("urlid") != "":
+		ref := &IssueReference{
+			Tracker: TrackerGitHub,
+			Kind:    IssueKindIssue,
+			Host:    group("host"),
+			ID:      group("urlid"),
+		}
+
+		switch group("kind") {
+		case "pull":
+			ref.Kind = IssueKindPullRequest
+		case "merge_requests":
+			ref.Kind = IssueKindMergeRequest
+		}
+
+		if group("gitlab") != "" || group("kind") == "merge_requests" ||
+			strings.Contains(ref.Host, "gitlab") {
+			ref.Tracker = TrackerGitLab
+		}
+
+		path := group("path")
+		i := strings.LastIndexByte(path, '/')
+		ref.Owner = path[:i]
+		ref.Repo = path[i+1:]
+
+		return ref
+	case group("repoid") != "":
+		return &IssueReference{
+			Tracker: TrackerRepository,
+			Kind:    IssueKindIssue,
+			Owner:   group("owner"),
+			Repo:    group("repo"),
+			ID:      group("repoid"),
+		}
+	case group("id") != "":
+		return &IssueReference{
+			Tracker: TrackerRepository,
+			Kind:    IssueKindIssue,
+			ID:      group("id"),
+		}
+	default:
+		return &IssueReference{
+			Tracker: TrackerJira,
+			Kind:    IssueKindIssue,
+			ID:      group("jirakey"),
+		}
+	}
+}
+
+// issueActionKeyword returns the action of the keyword at the end of the given
+// text, ignoring any trailing whitespace and ":" characters.
+func issueActionKeyword(text []byte) (IssueAction, bool) {
+	text = bytes.TrimRight(text, " \t:")
+
+	i := len(text)
+	for i > 0 && isTokenByte(text[i-1]) {
+		i--
+	}
+
+	action, ok := issueActionKeywords[strings.ToLower(string(text[i:]))]
+
+	return action, ok
+}
+
+// isIssueListSeparator reports if the given text only separates two references
+// in a list, like in "Fixes #1, #2 and #3".
+func isIssueListSeparator(text []byte) bool {
+	for _, f := range bytes.Fields(text) {
+		f = bytes.Trim(f, ",&")
+		if len(f) > 0 && !bytes.EqualFold(f, []byte("and")) {
+			return false
+		}
+	}
+
+	return len(bytes.TrimSpace(text)) > 0
+}
+
+func isIssuePrefixByte(c byte) bool {
+	return c == '/' || c == '&' || c == '#' || c == '_' || isTokenByte(c)
+}
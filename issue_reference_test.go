@@ -0,0 +1,317 @@
+package conventionalcommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawMessage_IssueReferences(t *testing.T) {
+	type ref struct {
+		Action  IssueAction
+		Tracker Tracker
+		Kind    IssueKind
+		Host    string
+		Owner   string
+		Repo    string
+		ID      string
+		Line    int
+		Text    string
+	}
+	tests := []struct {
+		name    string
+		parser  *IssueReferenceParser
+		message string
+		want    []ref
+	}{
+		{
+			name:    "empty",
+			message: "",
+			want:    []ref{},
+		},
+		{
+			name:    "header is ignored",
+			message: "fix: a broken thing (#12)",
+			want:    []ref{},
+		},
+		{
+			name:    "closing footer",
+			message: "fix: a broken thing\n\nCloses #12",
+			want: []ref{
+				{
+					Action:  IssueActionCloses,
+					Tracker: TrackerRepository,
+					Kind:    IssueKindIssue,
+					ID:      "12",
+					Line:    3,
+					Text:    "#12",
+				},
+			},
+		},
+		{
+			name:    "cross repository footer",
+			message: "fix: a broken thing\n\nFixes org/repo#34",
+			want: []ref{
+				{
+					Action:  IssueActionCloses,
+					Tracker: TrackerRepository,
+					Kind:    IssueKindIssue,
+					Owner:   "org",
+					Repo:    "repo",
+					ID:      "34",
+					Line:    3,
+					Text:    "org/repo#34",
+				},
+			},
+		},
+		{
+			name:    "jira footer",
+			message: "fix: a broken thing\n\nRefs: JIRA-123",
+			want: []ref{
+				{
+					Action:  IssueActionRefs,
+					Tracker: TrackerJira,
+					Kind:    IssueKindIssue,
+					ID:      "JIRA-123",
+					Line:    3,
+					Text:    "JIRA-123",
+				},
+			},
+		},
+		{
+			name: "list of references",
+			message: "fix: a broken thing\n\n" +
+				"Resolves: #1, #2 and PROJ-3\n" +
+				"Refs: #4",
+			want: []ref{
+				{
+					Action:  IssueActionCloses,
+					Tracker: TrackerRepository,
+					Kind:    IssueKindIssue,
+					ID:      "1",
+					Line:    3,
+					Text:    "#1",
+				},
+				{
+					Action:  IssueActionCloses,
+					Tracker: TrackerRepository,
+					Kind:    IssueKindIssue,
+					ID:      "2",
+					Line:    3,
+					Text:    "#2",
+				},
+				{
+					Action:  IssueActionCloses,
+					Tracker: TrackerJira,
+					Kind:    IssueKindIssue,
+					ID:      "PROJ-3",
+					Line:    3,
+					Text:    "PROJ-3",
+				},
+				{
+					Action:  IssueActionRefs,
+					Tracker: TrackerRepository,
+					Kind:    IssueKindIssue,
+					ID:      "4",
+					Line:    4,
+					Text:    "#4",
+				},
+			},
+		},
+		{
+			name: "URLs",
+			message: "fix: a broken thing\n\n" +
+				"Closes: https://github.com/org/repo/issues/5\n" +
+				"Refs: https://github.com/org/repo/pull/6\n" +
+				"Fixes: https://gitlab.example.com/group/sub/project" +
+				"/-/issues/7\n" +
+				"Refs: https://jira.example.com/browse/PROJ-8\n" +
+				"Refs: https://gitlab.com/g/p/-/merge_requests/9",
+			want: []ref{
+				{
+					Action:  IssueActionCloses,
+					Tracker: TrackerGitHub,
+					Kind:    IssueKindIssue,
+					Host:    "github.com",
+					Owner:   "org",
+					Repo:    "repo",
+					ID:      "5",
+					Line:    3,
+					Text:    "https://github.com/org/repo/issues/5",
+				},
+				{
+					Action:  IssueActionRefs,
+					Tracker: TrackerGitHub,
+					Kind:    IssueKindPullRequest,
+					Host:    "github.com",
+					Owner:   "org",
+					Repo:    "repo",
+					ID:      "6",
+					Line:    4,
+					Text:    "https://github.com/org/repo/pull/6",
+				},
+				{
+					Action:  IssueActionCloses,
+					Tracker: TrackerGitLab,
+					Kind:    IssueKindIssue,
+					Host:    "gitlab.example.com",
+					Owner:   "group/sub",
+					Repo:    "project",
+					ID:      "7",
+					Line:    5,
+					Text: "https://gitlab.example.com/group/sub/project" +
+						"/-/issues/7",
+				},
+				{
+					Action:  IssueActionRefs,
+					Tracker: TrackerJira,
+					Kind:    IssueKindIssue,
+					Host:    "jira.example.com",
+					ID:      "PROJ-8",
+					Line:    6,
+					Text:    "https://jira.example.com/browse/PROJ-8",
+				},
+				{
+					Action:  IssueActionRefs,
+					Tracker: TrackerGitLab,
+					Kind:    IssueKindMergeRequest,
+					Host:    "gitlab.com",
+					Owner:   "g",
+					Repo:    "p",
+					ID:      "9",
+					Line:    7,
+					Text:    "https://gitlab.com/g/p/-/merge_requests/9",
+				},
+			},
+		},
+		{
+			name: "body",
+			message: "fix: a broken thing\n\n" +
+				"This fixes #9, which was caused by UTF-8 handling in\n" +
+				"issue #10. See PROJ-11 for details.",
+			want: []ref{
+				{
+					Action:  IssueActionCloses,
+					Tracker: TrackerRepository,
+					Kind:    IssueKindIssue,
+					ID:      "9",
+					Line:    3,
+					Text:    "#9",
+				},
+				{
+					Action:  IssueActionRefs,
+					Tracker: TrackerRepository,
+					Kind:    IssueKindIssue,
+					ID:      "10",
+					Line:    4,
+					Text:    "#10",
+				},
+			},
+		},
+		{
+			name: "body with Jira projects",
+			parser: &IssueReferenceParser{
+				JiraProjects: []string{"PROJ"},
+			},
+			message: "fix: a broken thing\n\n" +
+				"This fixes UTF-8 handling, see PROJ-11 for details.",
+			want: []ref{
+				{
+					Action:  IssueActionRefs,
+					Tracker: TrackerJira,
+					Kind:    IssueKindIssue,
+					ID:      "PROJ-11",
+					Line:    3,
+					Text:    "PROJ-11",
+				},
+			},
+		},
+		{
+			name: "Jira-like words after keywords",
+			message: "fix: a broken thing\n\n" +
+				"This fixes UTF-8 handling, and resolves SHA-256 and\n" +
+				"ISO-8859 issues.",
+			want: []ref{},
+		},
+		{
+			name: "non-keyword footers",
+			message: "feat: drop legacy encodings\n\n" +
+				"BREAKING CHANGE: drop UTF-8 and ISO-8859 support, see #5\n" +
+				"Reviewed-by: PROJ-7",
+			want: []ref{
+				{
+					Action:  IssueActionRefs,
+					Tracker: TrackerRepository,
+					Kind:    IssueKindIssue,
+					ID:      "5",
+					Line:    3,
+					Text:    "#5",
+				},
+			},
+		},
+		{
+			name: "keyword within non-keyword footer",
+			message: "feat: drop legacy encodings\n\n" +
+				"BREAKING CHANGE: drop UTF-8, fixes PROJ-12 and #13",
+			want: []ref{
+				{
+					Action:  IssueActionCloses,
+					Tracker: TrackerRepository,
+					Kind:    IssueKindIssue,
+					ID:      "13",
+					Line:    3,
+					Text:    "#13",
+				},
+			},
+		},
+		{
+			name: "keyword within non-keyword footer with Jira projects",
+			parser: &IssueReferenceParser{
+				JiraProjects: []string{"PROJ"},
+			},
+			message: "feat: drop legacy encodings\n\n" +
+				"BREAKING CHANGE: drop UTF-8, fixes PROJ-12",
+			want: []ref{
+				{
+					Action:  IssueActionCloses,
+					Tracker: TrackerJira,
+					Kind:    IssueKindIssue,
+					ID:      "PROJ-12",
+					Line:    3,
+					Text:    "PROJ-12",
+				},
+			},
+		},
+		{
+			name: "ignores references within words and paths",
+			message: "fix: a broken thing\n\n" +
+				"See https://example.com/docs#12 and abc#13.",
+			want: []ref{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.parser == nil {
+				tt.parser = &IssueReferenceParser{}
+			}
+			msg := NewRawMessage([]byte(tt.message))
+
+			got := []ref{}
+			for _, r := range tt.parser.IssueReferences(msg) {
+				got = append(got, ref{
+					Action:  r.Action,
+					Tracker: r.Tracker,
+					Kind:    r.Kind,
+					Host:    r.Host,
+					Owner:   r.Owner,
+					Repo:    r.Repo,
+					ID:      r.ID,
+					Line:    r.Span.Line.Number,
+					Text:    r.Span.String(),
+				})
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package conventionalcommit
+
+import "regexp"
+
+// Kind classifies a commit message based on who or what wrote it.
+type Kind int
+
+const (
+	// KindRegular is used for all messages which are not generated by git or
+	// a git hosting service.
+	KindRegular Kind = iota
+
+	// KindMerge is used for git's merge messages, for example
+	// "Merge branch 'x'", "Merge tag 'v1.0.0'", or
+	// "Merge remote-tracking branch 'origin/main'".
+	KindMerge
+
+	// KindPullRequestMerge is used for merge messages generated by GitHub
+	// and GitLab, for example "Merge pull request #12 from owner/branch".
+	KindPullRequestMerge
+
+	// KindInitial is used for "Initial commit" messages generated by
+	// repository creation tools and git hosting services.
+	KindInitial
+)
+
+var (
+	mergeRegexp = regexp.MustCompile(
+		`^Merge (?:branch|branches|tag|tags|remote-tracking branch|` +
+			`remote-tracking branches|commit|commits) '`,
+	)
+	githubMergeRegexp   = regexp.MustCompile(`^Merge pull request #\d+ from `)
+	gitlabMergeRegexp   = regexp.MustCompile(`^See merge request \S+!\d+$`)
+	initialCommitRegexp = regexp.MustCompile(`^(?i:initial commit)\.?$`)
+)
+
+// Kind returns the classification of the message, allowing autogenerated
+// messages to be skipped by linters, or expanded by changelogs.
+func (s *RawMessage) Kind() Kind {
+	if len(s.Paragraphs) == 0 {
+		return KindRegular
+	}
+
+	header := s.Paragraphs[0].Lines[0].Content
+
+	switch {
+	case githubMergeRegexp.Match(header):
+		return KindPullRequestMerge
+	case mergeRegexp.Match(header):
+		for _, p := range s.Paragraphs[1:] {
+			for _, l := range p.Lines {
+				if gitlabMergeRegexp.Match(l.Content) {
+					return KindPullRequestMerge
+				}
+			}
+		}
+
+		return KindMerge
+	case initialCommitRegexp.Match(header):
+		return KindInitial
+	}
+
+	return KindRegular
+}
+
+// Autogenerated reports if messages of this kind are generated by git or a
+// git hosting service, rather than written by a human.
+func (k Kind) Autogenerated() bool {
+	return k != KindRegular
+}
+
+// String returns a lowercase name for the kind.
+func (k Kind) String() string {
+	switch k {
+	case KindRegular:
+		return "regular"
+	case KindMerge:
+		return "merge"
+	case KindPullRequestMerge:
+		return "pull-request-merge"
+	case KindInitial:
+		return "initial"
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,102 @@
+package conventionalcommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawMessage_Kind(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    Kind
+	}{
+		{
+			name:    "empty",
+			message: "",
+			want:    KindRegular,
+		},
+		{
+			name:    "conventional commit",
+			message: "feat: merge branch handling",
+			want:    KindRegular,
+		},
+		{
+			name:    "merge branch",
+			message: "Merge branch 'feature/thing'",
+			want:    KindMerge,
+		},
+		{
+			name:    "merge branch into",
+			message: "Merge branch 'main' of github.com:org/repo into main",
+			want:    KindMerge,
+		},
+		{
+			name:    "merge tag",
+			message: "Merge tag 'v1.0.0'",
+			want:    KindMerge,
+		},
+		{
+			name:    "merge remote-tracking branch",
+			message: "Merge remote-tracking branch 'origin/main'",
+			want:    KindMerge,
+		},
+		{
+			name:    "merge commit",
+			message: "Merge commit 'abcdef0' into main",
+			want:    KindMerge,
+		},
+		{
+			name: "github pull request",
+			message: "Merge pull request #12 from org/feature-branch\n\n" +
+				"feat: add a thing",
+			want: KindPullRequestMerge,
+		},
+		{
+			name: "gitlab merge request",
+			message: "Merge branch 'feature' into 'main'\n\n" +
+				"feat: add a thing\n\n" +
+				"See merge request group/project!34",
+			want: KindPullRequestMerge,
+		},
+		{
+			name:    "initial commit",
+			message: "Initial commit",
+			want:    KindInitial,
+		},
+		{
+			name:    "lowercase initial commit",
+			message: "initial commit.\n",
+			want:    KindInitial,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewRawMessage([]byte(tt.message))
+
+			got := msg.Kind()
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want != KindRegular, got.Autogenerated())
+		})
+	}
+}
+
+func TestKind_String(t *testing.T) {
+	tests := []struct {
+		kind Kind
+		want string
+	}{
+		{kind: KindRegular, want: "regular"},
+		{kind: KindMerge, want: "merge"},
+		{kind: KindPullRequestMerge, want: "pull-request-merge"},
+		{kind: KindInitial, want: "initial"},
+		{kind: Kind(99), want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.kind.String())
+		})
+	}
+}
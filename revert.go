@@ -0,0 +1,105 @@
+package conventionalcommit
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Revert describes the commit which is reverted by a revert commit.
+type Revert struct {
+	// Header is the header (first line) of the reverted commit.
+	Header string
+
+	// SHAs is the list of (possibly abbreviated) commit hashes of the
+	// reverted commits. It is empty if the revert commit does not reference
+	// any hashes.
+	SHAs []string
+}
+
+var (
+	revertHeaderRegexp = regexp.MustCompile(
+		`^(?i:revert)(?:\([^()]*\))?!?: (.+)$`,
+	)
+	gitRevertRegexp    = regexp.MustCompile(`^Revert "(.+)"$`)
+	gitRevertSHARegexp = regexp.MustCompile(
+		`^This reverts commit ([0-9a-fA-F]{7,64})\b`,
+	)
+	shaRegexp = regexp.MustCompile(`^[0-9a-fA-F]{7,64}$`)
+)
+
+// Revert returns details of the reverted commit if the message is a revert
+// commit, or nil if it is not.
+//
+// Both the conventional `revert: <header>` form, with reverted commits listed
+// in a "Refs" footer, and git's default `Revert "<header>"` form, with a `This
+// reverts commit <sha>.` body, are recognized.
+func (s *RawMessage) Revert() *Revert {
+	if len(s.Paragraphs) == 0 {
+		return nil
+	}
+
+	header := s.Paragraphs[0].Lines[0].Content
+
+	if m := gitRevertRegexp.FindSubmatch(header); m != nil {
+		r := &Revert{Header: string(m[1]), SHAs: []string{}}
+		for _, p := range s.Paragraphs[1:] {
+			for _, l := range p.Lines {
+				m := gitRevertSHARegexp.FindSubmatch(l.Content)
+				if m != nil {
+					r.SHAs = append(r.SHAs, string(m[1]))
+				}
+			}
+		}
+
+		return r
+	}
+
+	if m := revertHeaderRegexp.FindSubmatch(header); m != nil {
+		r := &Revert{Header: string(m[1]), SHAs: []string{}}
+		for _, t := range s.Footers() {
+			if !strings.EqualFold(t.Token, "Refs") {
+				continue
+			}
+
+			for _, v := range strings.Split(t.Value, ",") {
+				v = strings.TrimSpace(v)
+				if shaRegexp.MatchString(v) {
+					r.SHAs = append(r.SHAs, v)
+				}
+			}
+		}
+
+		return r
+	}
+
+	return nil
+}
+
+// Reverts reports if the commit with the given hash and header is the one
+// reverted. Hashes match if either is a prefix of the other, allowing for
+// abbreviated hashes. Both hashes must be at least 7 hex characters long, so
+// short strings do not match unrelated commits. When no hashes are known, the
+// header is compared instead.
+func (s *Revert) Reverts(sha string, header string) bool {
+	if len(s.SHAs) == 0 {
+		return s.Header == header
+	}
+
+	if !shaRegexp.MatchString(sha) {
+		return false
+	}
+
+	sha = strings.ToLower(sha)
+	for _, v := range s.SHAs {
+		if !shaRegexp.MatchString(v) {
+			continue
+		}
+
+		v = strings.ToLower(v)
+		if strings.HasPrefix(sha, v) || strings.HasPrefix(v, sha) {
+			return true
+		}
+	}
+
+	return false
+}
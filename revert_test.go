@@ -0,0 +1,145 @@
+package conventionalcommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawMessage_Revert(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    *Revert
+	}{
+		{
+			name:    "empty",
+			message: "",
+			want:    nil,
+		},
+		{
+			name:    "not a revert",
+			message: "fix: revert a broken thing\n\nRefs: 676104e",
+			want:    nil,
+		},
+		{
+			name: "conventional revert",
+			message: "revert: let us never again speak of the noodle incident" +
+				"\n\nRefs: 676104e, a215868",
+			want: &Revert{
+				Header: "let us never again speak of the noodle incident",
+				SHAs:   []string{"676104e", "a215868"},
+			},
+		},
+		{
+			name:    "conventional revert with scope and header",
+			message: "revert(api)!: feat(api): add users endpoint",
+			want: &Revert{
+				Header: "feat(api): add users endpoint",
+				SHAs:   []string{},
+			},
+		},
+		{
+			name: "git revert",
+			message: "Revert \"feat: add a thing\"\n\n" +
+				"This reverts commit " +
+				"0123456789abcdef0123456789abcdef01234567.\n",
+			want: &Revert{
+				Header: "feat: add a thing",
+				SHAs:   []string{"0123456789abcdef0123456789abcdef01234567"},
+			},
+		},
+		{
+			name: "git revert of revert",
+			message: "Revert \"Revert \"feat: add a thing\"\"\n\n" +
+				"This reverts commit abcdef0.",
+			want: &Revert{
+				Header: "Revert \"feat: add a thing\"",
+				SHAs:   []string{"abcdef0"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewRawMessage([]byte(tt.message))
+
+			got := msg.Revert()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRevert_Reverts(t *testing.T) {
+	tests := []struct {
+		name   string
+		revert *Revert
+		sha    string
+		header string
+		want   bool
+	}{
+		{
+			name:   "abbreviated hash",
+			revert: &Revert{Header: "feat: a", SHAs: []string{"abcdef0"}},
+			sha:    "ABCDEF0123456789abcdef0123456789abcdef01",
+			header: "feat: b",
+			want:   true,
+		},
+		{
+			name:   "different hash",
+			revert: &Revert{Header: "feat: a", SHAs: []string{"abcdef0"}},
+			sha:    "0123456",
+			header: "feat: a",
+			want:   false,
+		},
+		{
+			name:   "short hash",
+			revert: &Revert{Header: "feat: a", SHAs: []string{"abcdef0"}},
+			sha:    "a",
+			header: "feat: a",
+			want:   false,
+		},
+		{
+			name:   "short reverted hash",
+			revert: &Revert{Header: "feat: a", SHAs: []string{"abc"}},
+			sha:    "abcdef1234",
+			header: "feat: a",
+			want:   false,
+		},
+		{
+			name:   "not a hash",
+			revert: &Revert{Header: "feat: a", SHAs: []string{"abcdef0"}},
+			sha:    "abcdef0xyz",
+			header: "feat: a",
+			want:   false,
+		},
+		{
+			name:   "empty hash",
+			revert: &Revert{Header: "feat: a", SHAs: []string{"abcdef0"}},
+			sha:    "",
+			header: "feat: a",
+			want:   false,
+		},
+		{
+			name:   "header without hashes",
+			revert: &Revert{Header: "feat: a", SHAs: []string{}},
+			sha:    "0123456",
+			header: "feat: a",
+			want:   true,
+		},
+		{
+			name:   "different header without hashes",
+			revert: &Revert{Header: "feat: a", SHAs: []string{}},
+			sha:    "0123456",
+			header: "feat: b",
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.revert.Reverts(tt.sha, tt.header)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package conventionalcommit
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Scope describes a single commit scope, like "api" or "cli".
+type Scope struct {
+	// Name is the scope as written in commit message headers.
+	Name string
+
+	// Description is a short human readable description of the scope.
+	Description string
+
+	// Paths is a list of slash-separated directory paths, relative to the
+	// repository root, which belong to the scope.
+	Paths []string
+}
+
+// ScopeRegistry is an ordered collection of commit scopes, which are either
+// declared explicitly, or derived from the layout of a repository.
+type ScopeRegistry struct {
+	scopes []*Scope
+	index  map[string]*Scope
+}
+
+// NewScopeRegistry returns a ScopeRegistry containing the given scopes, in the
+// given order.
+func NewScopeRegistry(scopes ...*Scope) *ScopeRegistry {
+	r := &ScopeRegistry{
+		scopes: []*Scope{},
+		index:  map[string]*Scope{},
+	}
+
+	for _, scope := range scopes {
+		r.Add(scope)
+	}
+
+	return r
+}
+
+// Add adds the given scope to the registry. If a scope with the same name
+// already exists, it is replaced in place.
+func (s *ScopeRegistry) Add(scope *Scope) {
+	if _, ok := s.index[scope.Name]; ok {
+		for i, existing := range s.scopes {
+			if existing.Name == scope.Name {
+				s.scopes[i] = scope
+			}
+		}
+	} else {
+		s.scopes = append(s.scopes, scope)
+	}
+
+	s.index[scope.Name] = scope
+}
+
+// Get returns the scope with the given name, and false if it does not exist.
+func (s *ScopeRegistry) Get(name string) (*Scope, bool) {
+	scope, ok := s.index[name]
+
+	return scope, ok
+}
+
+// Scopes returns all scopes in the registry, in the order they were added.
+func (s *ScopeRegistry) Scopes() []*Scope {
+	r := make([]*Scope, len(s.scopes))
+	copy(r, s.scopes)
+
+	return r
+}
+
+// Names returns the names of all scopes in the registry, in the order they were
+// added.
+func (s *ScopeRegistry) Names() []string {
+	r := make([]string, 0, len(s.scopes))
+	for _, scope := range s.scopes {
+		r = append(r, scope.Name)
+	}
+
+	return r
+}
+
+// AddGlob adds a scope for each directory within root matching the given
+// slash-separated glob pattern, for example "services/*". Each scope is named
+// after the base name of its directory. When multiple directories share a base
+// name, they are added as paths of the same scope.
+func (s *ScopeRegistry) AddGlob(root string, pattern string) error {
+	matches, err := filepath.Glob(
+		filepath.Join(root, filepath.FromSlash(pattern)),
+	)
+	if err != nil {
+		return err
+	}
+
+	sort.Strings(matches)
+
+	for _, match := range matches {
+		info, err := os.Stat(match)
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			continue
+		}
+
+		rel, err := filepath.Rel(root, match)
+		if err != nil {
+			return err
+		}
+
+		s.addPath(filepath.ToSlash(rel))
+	}
+
+	return nil
+}
+
+// AddGoModules adds a scope for each Go module found within root, other than a
+// module in root itself. Each scope is named after the base name of the
+// module's directory. Hidden directories, and "vendor" and "testdata"
+// directories are skipped.
+func (s *ScopeRegistry) AddGoModules(root string) error {
+	dirs := []string{}
+
+	walk := func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			name := info.Name()
+			if p != root && (strings.HasPrefix(name, ".") ||
+				name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if info.Name() != "go.mod" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, filepath.Dir(p))
+		if err != nil {
+			return err
+		}
+		if rel != "." {
+			dirs = append(dirs, filepath.ToSlash(rel))
+		}
+
+		return nil
+	}
+
+	err := filepath.Walk(root, walk)
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range dirs {
+		s.addPath(dir)
+	}
+
+	return nil
+}
+
+// addPath adds the given slash-separated directory path to the scope named
+// after its base name, creating the scope if needed.
+func (s *ScopeRegistry) addPath(dir string) {
+	name := path.Base(dir)
+
+	if scope, ok := s.index[name]; ok {
+		for _, p := range scope.Paths {
+			if p == dir {
+				return
+			}
+		}
+
+		scope.Paths = append(scope.Paths, dir)
+
+		return
+	}
+
+	s.Add(&Scope{Name: name, Paths: []string{dir}})
+}
+
+// Infer returns the names of the scopes which the given slash-separated file
+// paths belong to, in registry order. Each file belongs to the scope with the
+// longest matching path. Files which do not belong to any scope are ignored.
+//
+// The paths are typically the output of "git diff --cached --name-only".
+func (s *ScopeRegistry) Infer(paths []string) []string {
+	found := map[*Scope]bool{}
+
+	for _, p := range paths {
+		p = path.Clean(p)
+
+		var match *Scope
+		longest := -1
+		for _, scope := range s.scopes {
+			for _, dir := range scope.Paths {
+				dir = path.Clean(dir)
+				if len(dir) > longest && pathWithin(p, dir) {
+					match = scope
+					longest = len(dir)
+				}
+			}
+		}
+
+		if match != nil {
+			found[match] = true
+		}
+	}
+
+	r := []string{}
+	for _, scope := range s.scopes {
+		if found[scope] {
+			r = append(r, scope.Name)
+		}
+	}
+
+	return r
+}
+
+// ScopeDiagnostics compares the scopes of the given header with the scopes
+// inferred from the given changed file paths with Infer, and returns a
+// Diagnostic for each mismatch:
+//
+//   - A registered header scope which none of the files belong to.
+//   - A header without scopes.
+//   - A scope the files belong to which is missing from the header, reported
+//     at the last header scope, unless a header scope was already reported.
+//
+// Header scopes which are not registered are left to ScopeFix. Nothing is
+// reported when the files do not belong to any scope.
+func (s *ScopeRegistry) ScopeDiagnostics(
+	h *Header,
+	paths []string,
+) []*Diagnostic {
+	r := []*Diagnostic{}
+
+	inferred := s.Infer(paths)
+	if len(inferred) == 0 {
+		return r
+	}
+
+	if len(h.Scopes) == 0 {
+		if h.TypeSpan == nil {
+			return r
+		}
+
+		return append(r, &Diagnostic{
+			Span: &Span{
+				Line:  h.Line,
+				Start: h.TypeSpan.End,
+				End:   h.TypeSpan.End,
+			},
+			Message: fmt.Sprintf(
+				"missing scope, changed files belong to %s",
+				quoteNames(inferred),
+			),
+		})
+	}
+
+	used := map[string]bool{}
+	for _, hs := range h.Scopes {
+		scope, ok := s.Lookup(hs)
+		if !ok {
+			continue
+		}
+
+		used[scope.Name] = true
+		if !containsString(inferred, scope.Name) {
+			r = append(r, &Diagnostic{
+				Span: hs.Span,
+				Message: fmt.Sprintf(
+					"scope %q does not match changed files, "+
+						"which belong to %s",
+					hs.Name, quoteNames(inferred),
+				),
+			})
+		}
+	}
+
+	missing := []string{}
+	for _, name := range inferred {
+		if !used[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 && len(r) == 0 {
+		r = append(r, &Diagnostic{
+			Span: h.Scopes[len(h.Scopes)-1].Span,
+			Message: fmt.Sprintf(
+				"changed files also belong to %s", quoteNames(missing),
+			),
+		})
+	}
+
+	return r
+}
+
+// quoteNames returns the given names quoted and separated by commas.
+func quoteNames(names []string) string {
+	quoted := make([]string, 0, len(names))
+	for _, name := range names {
+		quoted = append(quoted, fmt.Sprintf("%q", name))
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pathWithin reports if the slash-separated path p is dir, or is within dir.
+func pathWithin(p string, dir string) bool {
+	if dir == "." {
+		return true
+	}
+
+	return p == dir || strings.HasPrefix(p, dir+"/")
+}
+
+// Lookup returns the registered scope for the given header scope. Nested scopes
+// like "api/users" match the registered scope with the longest matching
+// prefix, so "api/users" matches "api" if there is no "api/users" scope.
+func (s *ScopeRegistry) Lookup(scope *HeaderScope) (*Scope, bool) {
+	if len(scope.Parts) < 2 {
+		return s.Get(scope.Name)
+	}
+
+	size := 0
+	for _, part := range scope.Parts {
+		size += len(part)
+	}
+	sep := (len(scope.Name) - size) / (len(scope.Parts) - 1)
+
+	end := len(scope.Name)
+	for n := len(scope.Parts) - 1; n >= 0; n-- {
+		if r, ok := s.Get(scope.Name[:end]); ok {
+			return r, true
+		}
+
+		end -= len(scope.Parts[n]) + sep
+	}
+
+	return nil, false
+}
@@ -0,0 +1,329 @@
+package conventionalcommit
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTree creates the given files within a new temporary directory, and
+// returns the path to the directory. Paths ending with "/" are created as
+// directories.
+func createTree(t *testing.T, paths ...string) string {
+	root := t.TempDir()
+
+	for _, p := range paths {
+		full := filepath.Join(root, filepath.FromSlash(p))
+		if p[len(p)-1] == '/' {
+			require.NoError(t, os.MkdirAll(full, 0o755))
+
+			continue
+		}
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(full), 0o755))
+		require.NoError(t, ioutil.WriteFile(full, []byte{}, 0o600))
+	}
+
+	return root
+}
+
+func TestScopeRegistry_Add(t *testing.T) {
+	r := NewScopeRegistry(
+		&Scope{Name: "api", Paths: []string{"api"}},
+		&Scope{Name: "cli"},
+	)
+
+	r.Add(&Scope{Name: "docs", Paths: []string{"docs"}})
+	r.Add(&Scope{Name: "api", Paths: []string{"services/api"}})
+
+	assert.Equal(t, []string{"api", "cli", "docs"}, r.Names())
+
+	api, ok := r.Get("api")
+	assert.True(t, ok)
+	assert.Equal(t, []string{"services/api"}, api.Paths)
+
+	_, ok = r.Get("API")
+	assert.False(t, ok)
+}
+
+func TestScopeRegistry_AddGlob(t *testing.T) {
+	root := createTree(t,
+		"services/api/main.go",
+		"services/users/",
+		"services/README.md",
+		"libs/users/go.mod",
+		"libs/auth/go.mod",
+	)
+
+	r := NewScopeRegistry(&Scope{Name: "ci"})
+	err := r.AddGlob(root, "services/*")
+	require.NoError(t, err)
+	err = r.AddGlob(root, "libs/*")
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]*Scope{
+			{Name: "ci"},
+			{Name: "api", Paths: []string{"services/api"}},
+			{Name: "users", Paths: []string{"services/users", "libs/users"}},
+			{Name: "auth", Paths: []string{"libs/auth"}},
+		},
+		r.Scopes(),
+	)
+}
+
+func TestScopeRegistry_AddGlob_BadPattern(t *testing.T) {
+	r := NewScopeRegistry()
+
+	err := r.AddGlob(t.TempDir(), "services/[")
+
+	assert.Equal(t, filepath.ErrBadPattern, err)
+}
+
+func TestScopeRegistry_AddGoModules(t *testing.T) {
+	root := createTree(t,
+		"go.mod",
+		"cmd/tool/main.go",
+		"api/go.mod",
+		"api/v2/go.mod",
+		"libs/auth/go.mod",
+		"vendor/example.com/dep/go.mod",
+		"internal/testdata/mod/go.mod",
+		".git/go.mod",
+	)
+
+	r := NewScopeRegistry()
+	err := r.AddGoModules(root)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		[]*Scope{
+			{Name: "api", Paths: []string{"api"}},
+			{Name: "v2", Paths: []string{"api/v2"}},
+			{Name: "auth", Paths: []string{"libs/auth"}},
+		},
+		r.Scopes(),
+	)
+}
+
+func TestScopeRegistry_AddGoModules_MissingRoot(t *testing.T) {
+	r := NewScopeRegistry()
+
+	err := r.AddGoModules(filepath.Join(t.TempDir(), "nope"))
+
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestScopeRegistry_Infer(t *testing.T) {
+	r := NewScopeRegistry(
+		&Scope{Name: "api", Paths: []string{"api", "services/api/"}},
+		&Scope{Name: "v2", Paths: []string{"api/v2"}},
+		&Scope{Name: "cli", Paths: []string{"cmd/cli"}},
+		&Scope{Name: "deps"},
+	)
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{
+			name:  "no paths",
+			paths: nil,
+			want:  []string{},
+		},
+		{
+			name:  "unknown paths",
+			paths: []string{"README.md", "apis/main.go"},
+			want:  []string{},
+		},
+		{
+			name:  "single scope",
+			paths: []string{"api/main.go", "./services/api/handler.go"},
+			want:  []string{"api"},
+		},
+		{
+			name:  "longest path wins",
+			paths: []string{"api/v2/main.go"},
+			want:  []string{"v2"},
+		},
+		{
+			name: "multiple scopes in registry order",
+			paths: []string{
+				"cmd/cli/main.go",
+				"api/v2/main.go",
+				"api/main.go",
+				"go.mod",
+			},
+			want: []string{"api", "v2", "cli"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.Infer(tt.paths)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScopeRegistry_ScopeDiagnostics(t *testing.T) {
+	r := NewScopeRegistry(
+		&Scope{Name: "api", Paths: []string{"api"}},
+		&Scope{Name: "cli", Paths: []string{"cmd/cli"}},
+		&Scope{Name: "web", Paths: []string{"web"}},
+	)
+
+	type diagnostic struct {
+		start   int
+		end     int
+		message string
+	}
+	tests := []struct {
+		name   string
+		header string
+		paths  []string
+		want   []diagnostic
+	}{
+		{
+			name:   "matching scope",
+			header: "feat(api): add a thing",
+			paths:  []string{"api/main.go", "README.md"},
+			want:   []diagnostic{},
+		},
+		{
+			name:   "nested scope",
+			header: "feat(api/users): add a thing",
+			paths:  []string{"api/users.go"},
+			want:   []diagnostic{},
+		},
+		{
+			name:   "no inferred scopes",
+			header: "feat(web): add a thing",
+			paths:  []string{"README.md"},
+			want:   []diagnostic{},
+		},
+		{
+			name:   "unknown scope",
+			header: "feat(db): add a thing",
+			paths:  []string{"api/main.go"},
+			want: []diagnostic{
+				{5, 7, `changed files also belong to "api"`},
+			},
+		},
+		{
+			name:   "mismatched scope",
+			header: "feat(web): add a thing",
+			paths:  []string{"api/main.go", "cmd/cli/main.go"},
+			want: []diagnostic{
+				{
+					5, 8, `scope "web" does not match changed files, ` +
+						`which belong to "api", "cli"`,
+				},
+			},
+		},
+		{
+			name:   "missing scope",
+			header: "feat: add a thing",
+			paths:  []string{"web/index.html"},
+			want: []diagnostic{
+				{4, 4, `missing scope, changed files belong to "web"`},
+			},
+		},
+		{
+			name:   "incomplete scopes",
+			header: "feat(api, cli): add a thing",
+			paths:  []string{"api/main.go", "cmd/cli/main.go", "web/x"},
+			want: []diagnostic{
+				{10, 13, `changed files also belong to "web"`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := ParseHeader(&Line{Number: 1, Content: []byte(tt.header)})
+			require.NoError(t, err)
+
+			got := []diagnostic{}
+			for _, d := range r.ScopeDiagnostics(h, tt.paths) {
+				assert.Equal(t, h.Line, d.Span.Line)
+				got = append(got, diagnostic{
+					d.Span.Start, d.Span.End, d.Message,
+				})
+			}
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestScopeRegistry_Lookup(t *testing.T) {
+	api := &Scope{Name: "api"}
+	users := &Scope{Name: "api.v2.users"}
+	r := NewScopeRegistry(api, users)
+
+	tests := []struct {
+		name   string
+		parser *HeaderParser
+		header string
+		want   *Scope
+	}{
+		{
+			name:   "exact",
+			header: "fix(api): a broken thing",
+			want:   api,
+		},
+		{
+			name:   "unknown",
+			header: "fix(cli): a broken thing",
+			want:   nil,
+		},
+		{
+			name:   "parent",
+			header: "fix(api/v2/groups): a broken thing",
+			want:   api,
+		},
+		{
+			name:   "unknown parent",
+			header: "fix(web/api): a broken thing",
+			want:   nil,
+		},
+		{
+			name:   "nested exact",
+			parser: &HeaderParser{ScopeSeparator: "."},
+			header: "fix(api.v2.users): a broken thing",
+			want:   users,
+		},
+		{
+			name:   "nested child",
+			parser: &HeaderParser{ScopeSeparator: "."},
+			header: "fix(api.v2.users.auth): a broken thing",
+			want:   users,
+		},
+		{
+			name:   "multi-character separator",
+			parser: &HeaderParser{ScopeSeparator: "::"},
+			header: "fix(api::v2): a broken thing",
+			want:   api,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := tt.parser
+			if parser == nil {
+				parser = &HeaderParser{}
+			}
+			h, err := parser.Parse(&Line{Content: []byte(tt.header)})
+			require.NoError(t, err)
+
+			got, ok := r.Lookup(h.Scopes[0])
+
+			assert.Equal(t, tt.want != nil, ok)
+			assert.Same(t, tt.want, got)
+		})
+	}
+}
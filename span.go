@@ -0,0 +1,19 @@
+package conventionalcommit
+
+// Span represents a continuous range of bytes within the Content of a single
+// Line. Start and End are byte offsets, with End being exclusive.
+type Span struct {
+	Line  *Line
+	Start int
+	End   int
+}
+
+// Bytes returns the content of the line covered by the span.
+func (s *Span) Bytes() []byte {
+	return s.Line.Content[s.Start:s.End]
+}
+
+// String returns the content of the line covered by the span as a string.
+func (s *Span) String() string {
+	return string(s.Bytes())
+}
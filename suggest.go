@@ -0,0 +1,157 @@
+package conventionalcommit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// maxSuggestDistance is the largest edit distance at which a registered name is
+// suggested as a correction.
+const maxSuggestDistance = 2
+
+// Suggest returns the name of the registered type which the given unknown type
+// was most likely meant to be, based on the aliases of each type and on edit
+// distance. It returns false if there is no likely candidate.
+func (s *TypeRegistry) Suggest(name string) (string, bool) {
+	lower := strings.ToLower(name)
+
+	for _, t := range s.types {
+		for _, alias := range t.Aliases {
+			if strings.ToLower(alias) == lower {
+				return t.Name, true
+			}
+		}
+	}
+
+	return closestName(lower, s.Names())
+}
+
+// Suggest returns the name of the registered scope which the given unknown
+// scope was most likely meant to be, based on edit distance. It returns false
+// if there is no likely candidate.
+func (s *ScopeRegistry) Suggest(name string) (string, bool) {
+	return closestName(strings.ToLower(name), s.Names())
+}
+
+// TypeFix returns a Fix which replaces the type of the given header with the
+// suggested type, or nil if the type is registered, there is no suggestion, or
+// the type is not written out in the header.
+func (s *TypeRegistry) TypeFix(h *Header) *Fix {
+	if h.TypeSpan == nil {
+		return nil
+	}
+	if _, ok := s.Get(h.Type); ok {
+		return nil
+	}
+
+	suggestion, ok := s.Suggest(h.Type)
+	if !ok {
+		return nil
+	}
+
+	return &Fix{
+		Span:        h.TypeSpan,
+		Replacement: suggestion,
+		Description: fmt.Sprintf(
+			"unknown type %q, did you mean %q?", h.Type, suggestion,
+		),
+	}
+}
+
+// ScopeFix returns a Fix which replaces the given header scope with the
+// suggested scope, or nil if the scope is registered, or there is no
+// suggestion. Nested scopes are matched with Lookup, and never get a
+// suggestion, as only part of them may be wrong.
+func (s *ScopeRegistry) ScopeFix(scope *HeaderScope) *Fix {
+	if _, ok := s.Lookup(scope); ok || len(scope.Parts) > 1 {
+		return nil
+	}
+
+	suggestion, ok := s.Suggest(scope.Name)
+	if !ok {
+		return nil
+	}
+
+	return &Fix{
+		Span:        scope.Span,
+		Replacement: suggestion,
+		Description: fmt.Sprintf(
+			"unknown scope %q, did you mean %q?", scope.Name, suggestion,
+		),
+	}
+}
+
+// closestName returns the candidate with the smallest edit distance to name,
+// as long as the distance is small relative to the length of both. The first
+// candidate wins when several have the same distance.
+func closestName(name string, candidates []string) (string, bool) {
+	best := ""
+	bestDistance := maxSuggestDistance + 1
+
+	for _, c := range candidates {
+		d := editDistance(name, strings.ToLower(c))
+
+		// Avoid suggesting completely different short names, like "ci" for
+		// "db".
+		if d >= len(c) || d >= len(name) {
+			continue
+		}
+
+		if d < bestDistance {
+			best = c
+			bestDistance = d
+		}
+	}
+
+	return best, best != ""
+}
+
+// editDistance returns the optimal string alignment distance between a and b,
+// which is the Levenshtein distance extended with transpositions of adjacent
+// characters, so that "feta" is one edit away from "feat".
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			d := minInt(
+				rows[i-1][j]+1,
+				rows[i][j-1]+1,
+				rows[i-1][j-1]+cost,
+			)
+
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				d = minInt(d, rows[i-2][j-2]+1)
+			}
+
+			rows[i][j] = d
+		}
+	}
+
+	return rows[len(ra)][len(rb)]
+}
+
+func minInt(values ...int) int {
+	r := values[0]
+	for _, v := range values[1:] {
+		if v < r {
+			r = v
+		}
+	}
+
+	return r
+}
@@ -0,0 +1,156 @@
+package conventionalcommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeRegistry_Suggest(t *testing.T) {
+	r := DefaultTypeRegistry()
+
+	tests := []struct {
+		name   string
+		typ    string
+		want   string
+		wantOK bool
+	}{
+		{name: "alias", typ: "feature", want: "feat", wantOK: true},
+		{name: "uppercase alias", typ: "BugFix", want: "fix", wantOK: true},
+		{name: "doc alias", typ: "doc", want: "docs", wantOK: true},
+		{name: "transposition", typ: "feta", want: "feat", wantOK: true},
+		{name: "deletion", typ: "refator", want: "refactor", wantOK: true},
+		{name: "extra letter", typ: "fixx", want: "fix", wantOK: true},
+		{name: "two edits", typ: "chroe", want: "chore", wantOK: true},
+		{name: "too short", typ: "db", want: "", wantOK: false},
+		{name: "too different", typ: "wibble", want: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := r.Suggest(tt.typ)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestScopeRegistry_Suggest(t *testing.T) {
+	r := NewScopeRegistry(
+		&Scope{Name: "api"},
+		&Scope{Name: "users"},
+		&Scope{Name: "cli"},
+	)
+
+	tests := []struct {
+		name   string
+		scope  string
+		want   string
+		wantOK bool
+	}{
+		{name: "typo", scope: "usres", want: "users", wantOK: true},
+		{name: "case", scope: "API", want: "api", wantOK: true},
+		{name: "first closest", scope: "apl", want: "api", wantOK: true},
+		{name: "too different", scope: "web", want: "", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := r.Suggest(tt.scope)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestTypeRegistry_TypeFix(t *testing.T) {
+	r := DefaultTypeRegistry()
+
+	tests := []struct {
+		name            string
+		parser          *HeaderParser
+		header          string
+		want            string
+		wantDescription string
+	}{
+		{
+			name:   "known type",
+			header: "Feat: add a thing",
+		},
+		{
+			name:   "no suggestion",
+			header: "wibble: add a thing",
+		},
+		{
+			name: "type from gitmoji",
+			parser: &HeaderParser{Gitmoji: NewGitmojiTable(
+				&Gitmoji{Emoji: "✨", Type: "feature"},
+			)},
+			header: "✨ add a thing",
+		},
+		{
+			name:            "alias",
+			header:          "feature(api): add a thing",
+			want:            "feat(api): add a thing",
+			wantDescription: `unknown type "feature", did you mean "feat"?`,
+		},
+		{
+			name:            "typo after gitmoji",
+			parser:          &HeaderParser{Gitmoji: DefaultGitmojiTable()},
+			header:          "🐛 fxi: a broken thing",
+			want:            "🐛 fix: a broken thing",
+			wantDescription: `unknown type "fxi", did you mean "fix"?`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parser := tt.parser
+			if parser == nil {
+				parser = &HeaderParser{}
+			}
+			msg := NewRawMessage([]byte(tt.header))
+			h, err := parser.Parse(msg.Lines[0])
+			require.NoError(t, err)
+
+			fix := r.TypeFix(h)
+
+			if tt.want == "" {
+				assert.Nil(t, fix)
+
+				return
+			}
+
+			require.NotNil(t, fix)
+			assert.Equal(t, tt.wantDescription, fix.Description)
+			got, _ := msg.ApplyFixes(fix)
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestScopeRegistry_ScopeFix(t *testing.T) {
+	r := NewScopeRegistry(&Scope{Name: "api"}, &Scope{Name: "users"})
+	msg := NewRawMessage(
+		[]byte("fix(api, usres, api/v2, web, clients/v2): a broken thing"),
+	)
+	h, err := ParseHeader(msg.Lines[0])
+	require.NoError(t, err)
+
+	fixes := []*Fix{}
+	for _, scope := range h.Scopes {
+		if fix := r.ScopeFix(scope); fix != nil {
+			fixes = append(fixes, fix)
+		}
+	}
+
+	require.Len(t, fixes, 1)
+	assert.Equal(t,
+		`unknown scope "usres", did you mean "users"?`, fixes[0].Description,
+	)
+	got, _ := msg.ApplyFixes(fixes...)
+	assert.Equal(t,
+		"fix(api, users, api/v2, web, clients/v2): a broken thing",
+		got.String(),
+	)
+}
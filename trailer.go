@@ -0,0 +1,652 @@
+package conventionalcommit
+
+import (
+	"bytes"
+	"strings"
+)
+
+// signedOffBy is the trailer token git itself generates. A trailer block which
+// contains it is allowed to also contain non-trailer lines, matching the
+// behavior of git interpret-trailers.
+const signedOffBy = "Signed-off-by"
+
+const (
+	// commentChar starts comment lines, which git ignores in trailer blocks
+	// and at the end of the log message.
+	commentChar = '#'
+
+	// patchDivider starts the line which separates the log message from a
+	// patch, as in the output of git format-patch.
+	patchDivider = "---"
+
+	// scissors is the line above which git commit --verbose places the diff.
+	// Everything below it is not part of the log message.
+	scissors = "# ------------------------ >8 ------------------------"
+)
+
+// conventionalFooters parses footers as defined by the conventional commits
+// specification, which also allow a " #" separator and the "BREAKING CHANGE"
+// token.
+var conventionalFooters = &TrailerParser{
+	Separators: ":#",
+	Tokens:     []string{"BREAKING CHANGE"},
+}
+
+// Trailer represents a single trailer (or footer) within the last paragraph of
+// a commit message, defined as; A token, followed by a separator, followed by
+// a value. Values may continue onto following lines which start with
+// whitespace.
+type Trailer struct {
+	// Token is the key of the trailer, for example "Signed-off-by".
+	Token string
+
+	// Separator is the separator character the trailer was written with,
+	// for example ":" or "#".
+	Separator string
+
+	// Value is the whitespace trimmed value of the trailer, with any
+	// continuation lines unfolded into a single line. When Separator is "#",
+	// the "#" is retained as the first character of the value.
+	Value string
+
+	// Lines is the list of lines which the trailer spans, including any
+	// continuation lines.
+	Lines Lines
+}
+
+// IfExists defines what action is taken when a trailer with the same token
+// already exists, mirroring git interpret-trailers' "ifExists" option.
+type IfExists int
+
+const (
+	// IfExistsAddIfDifferentNeighbor adds the trailer unless the trailer
+	// next to where it would be placed has the same token and value. This is
+	// the default, as it is in git.
+	IfExistsAddIfDifferentNeighbor IfExists = iota
+
+	// IfExistsAddIfDifferent adds the trailer unless any trailer with the
+	// same token and value already exists.
+	IfExistsAddIfDifferent
+
+	// IfExistsAdd always adds the trailer.
+	IfExistsAdd
+
+	// IfExistsReplace removes the existing trailer, and adds the new one in
+	// its place.
+	IfExistsReplace
+
+	// IfExistsDoNothing leaves existing trailers untouched.
+	IfExistsDoNothing
+)
+
+// IfMissing defines what action is taken when no trailer with the same token
+// exists, mirroring git interpret-trailers' "ifMissing" option.
+type IfMissing int
+
+const (
+	// IfMissingAdd adds the trailer. This is the default, as it is in git.
+	IfMissingAdd IfMissing = iota
+
+	// IfMissingDoNothing does not add the trailer.
+	IfMissingDoNothing
+)
+
+// Where defines where a trailer is placed, mirroring git interpret-trailers'
+// "where" option.
+type Where int
+
+const (
+	// WhereEnd places the trailer at the end of the trailer block, or after
+	// the last existing trailer with the same token. This is the default, as
+	// it is in git.
+	WhereEnd Where = iota
+
+	// WhereStart places the trailer at the start of the trailer block, or
+	// before the first existing trailer with the same token.
+	WhereStart
+
+	// WhereAfter places the trailer directly after the last existing trailer
+	// with the same token.
+	WhereAfter
+
+	// WhereBefore places the trailer directly before the first existing
+	// trailer with the same token.
+	WhereBefore
+)
+
+func (w Where) afterOrEnd() bool {
+	return w == WhereEnd || w == WhereAfter
+}
+
+func (w Where) middle() bool {
+	return w == WhereAfter || w == WhereBefore
+}
+
+// TrailerEdit describes a single trailer to add or replace in a commit
+// message. The zero values of Where, IfExists and IfMissing match the defaults
+// of git interpret-trailers.
+type TrailerEdit struct {
+	Token string
+	Value string
+
+	Where     Where
+	IfExists  IfExists
+	IfMissing IfMissing
+}
+
+// TrailerParser defines which lines are recognized as trailers. The zero value
+// uses the same rules as git interpret-trailers with its default config.
+type TrailerParser struct {
+	// Separators is the set of characters which separate a token from its
+	// value, like git's "trailer.separators" option. Defaults to ":". New
+	// trailers are written with the first separator.
+	Separators string
+
+	// Tokens are recognized in addition to tokens consisting of alphanumeric
+	// characters and "-", and may contain spaces, like "BREAKING CHANGE".
+	Tokens []string
+}
+
+// Trailers returns all trailers within the trailer block of the message, using
+// the same rules as git interpret-trailers.
+func (s *RawMessage) Trailers() []*Trailer {
+	return (&TrailerParser{}).Trailers(s)
+}
+
+// Footers returns all footers within the trailer block of the message, as
+// defined by the conventional commits specification. Unlike git trailers,
+// footers may use a " #" separator, as in "Closes #12", and the
+// "BREAKING CHANGE" token. The "#" is retained as the first character of such
+// values.
+func (s *RawMessage) Footers() []*Trailer {
+	return conventionalFooters.Trailers(s)
+}
+
+// EditTrailers applies each of the given edits in order, using the same rules
+// as git interpret-trailers, and returns the resulting message.
+func (s *RawMessage) EditTrailers(edits ...*TrailerEdit) *RawMessage {
+	return (&TrailerParser{}).EditTrailers(s, edits...)
+}
+
+// RemoveTrailers removes all trailers with the given token, and returns the
+// resulting message. Tokens are compared case-insensitively.
+func (s *RawMessage) RemoveTrailers(token string) *RawMessage {
+	return (&TrailerParser{}).RemoveTrailers(s, token)
+}
+
+// Trailers returns all trailers within the trailer block of the message. The
+// trailer block is the last paragraph of the log message, as long as it is not
+// also the first paragraph, and it consists of only trailers and comment
+// lines. Non-trailer lines are allowed if at least 25% of lines are trailers
+// and a Signed-off-by trailer is present.
+//
+// Like git, the log message excludes a patch starting with a "---" line, text
+// below a scissors line, and trailing comment lines.
+func (s *TrailerParser) Trailers(msg *RawMessage) []*Trailer {
+	r := []*Trailer{}
+
+	for _, item := range s.blockItems(msg) {
+		if item.Token != "" {
+			r = append(r, item)
+		}
+	}
+
+	return r
+}
+
+// EditTrailers applies each of the given edits in order, using the same rules
+// as git interpret-trailers, and returns the resulting message. New trailers
+// are placed before any text excluded from the log message. Existing trailers
+// are kept as written, while git rewrites them using the first separator.
+// Comment lines within the trailer block are removed, as they are by git.
+func (s *TrailerParser) EditTrailers(
+	msg *RawMessage,
+	edits ...*TrailerEdit,
+) *RawMessage {
+	log := logLines(msg)
+	p := s.paragraph(log)
+	items := s.paragraphItems(p)
+
+	for _, edit := range edits {
+		items = applyTrailerEdit(items, edit, s.separator())
+	}
+
+	return msg.replaceTrailerItems(log, p, items)
+}
+
+// RemoveTrailers removes all trailers with the given token, and returns the
+// resulting message. Tokens are compared case-insensitively.
+func (s *TrailerParser) RemoveTrailers(
+	msg *RawMessage,
+	token string,
+) *RawMessage {
+	log := logLines(msg)
+	p := s.paragraph(log)
+	items := []*Trailer{}
+
+	for _, item := range s.paragraphItems(p) {
+		if !strings.EqualFold(item.Token, token) {
+			items = append(items, item)
+		}
+	}
+
+	return msg.replaceTrailerItems(log, p, items)
+}
+
+// separator returns the separator used for new trailers.
+func (s *TrailerParser) separator() string {
+	if s.Separators == "" {
+		return ":"
+	}
+
+	return s.Separators[:1]
+}
+
+// logLines returns the lines of the given message which form its log message,
+// matching git's find_end_of_log_message. Everything from a "---" line or a
+// scissors line onwards is excluded, followed by any trailing comment and
+// empty lines.
+func logLines(msg *RawMessage) Lines {
+	end := len(msg.Lines)
+	for i, l := range msg.Lines {
+		if isPatchDivider(l) || string(l.Content) == scissors {
+			end = i
+
+			break
+		}
+	}
+
+	for end > 0 {
+		c := msg.Lines[end-1].Content
+		if len(c) > 0 && c[0] != commentChar {
+			break
+		}
+		end--
+	}
+
+	return msg.Lines[:end]
+}
+
+// isPatchDivider reports if the given line is a "---" line followed by
+// whitespace, which git treats as the start of a patch.
+func isPatchDivider(l *Line) bool {
+	if !bytes.HasPrefix(l.Content, []byte(patchDivider)) {
+		return false
+	}
+	if len(l.Content) == len(patchDivider) {
+		return len(l.Break) > 0
+	}
+
+	return isSpace(l.Content[len(patchDivider)])
+}
+
+// paragraph returns the paragraph which forms the trailer block of the given
+// log message lines, or nil if it does not have one.
+func (s *TrailerParser) paragraph(log Lines) *Paragraph {
+	paragraphs := NewParagraphs(log)
+	if len(paragraphs) < 2 {
+		return nil
+	}
+
+	p := paragraphs[len(paragraphs)-1]
+	items := s.items(p.Lines)
+
+	trailers := 0
+	others := 0
+	recognized := false
+	for _, item := range items {
+		if item.Token == "" {
+			others += len(item.Lines)
+
+			continue
+		}
+
+		trailers += len(item.Lines)
+		if strings.EqualFold(item.Token, signedOffBy) {
+			recognized = true
+		}
+	}
+
+	if trailers == 0 || (others > 0 && (!recognized || trailers*3 < others)) {
+		return nil
+	}
+
+	return p
+}
+
+// blockItems returns every item in the trailer block of the given message.
+// Lines which are not trailers are returned as items with an empty Token.
+func (s *TrailerParser) blockItems(msg *RawMessage) []*Trailer {
+	return s.paragraphItems(s.paragraph(logLines(msg)))
+}
+
+// paragraphItems returns every item in the given trailer block, which may be
+// nil.
+func (s *TrailerParser) paragraphItems(p *Paragraph) []*Trailer {
+	if p == nil {
+		return []*Trailer{}
+	}
+
+	return s.items(p.Lines)
+}
+
+// replaceTrailerItems returns a new message with the trailer block p within
+// the given log message lines replaced by the given items. If p is nil, a new
+// trailer block is created after the log message if needed. Like git, the
+// trailer block always ends with a line break.
+func (s *RawMessage) replaceTrailerItems(
+	log Lines,
+	p *Paragraph,
+	items []*Trailer,
+) *RawMessage {
+	lb := s.lineBreak()
+
+	var block []byte
+	for _, item := range items {
+		if item.Lines != nil {
+			for _, l := range item.Lines {
+				block = append(block, l.Content...)
+				block = append(block, lb...)
+			}
+		} else {
+			block = append(block, item.Token...)
+			block = append(block, item.Separator...)
+			block = append(block, ' ')
+			block = append(block, item.Value...)
+			block = append(block, lb...)
+		}
+	}
+
+	var head, tail []byte
+	if p != nil {
+		first := p.Lines[0].Number - 1
+		last := p.Lines[len(p.Lines)-1].Number
+		head = s.Lines[:first].Bytes()
+		tail = s.Lines[last:].Bytes()
+
+		if len(block) == 0 {
+			// Drop the trailer block together with the blank line(s)
+			// separating it from the preceding paragraph.
+			paragraphs := NewParagraphs(log)
+			prev := paragraphs[len(paragraphs)-2]
+			end := prev.Lines[len(prev.Lines)-1].Number
+			head = s.Lines[:end].Bytes()
+			head = head[:len(head)-len(s.Lines[end-1].Break)]
+			tail = append(
+				[]byte{}, s.Lines[last-1].Break...,
+			)
+			tail = append(tail, s.Lines[last:].Bytes()...)
+		}
+	} else {
+		if len(block) == 0 {
+			return NewRawMessage(s.Bytes())
+		}
+
+		end := len(log)
+		for end > 0 && len(log[end-1].Content) == 0 {
+			end--
+		}
+		head = append([]byte{}, s.Lines[:end].Bytes()...)
+		tail = s.Lines[end:].Bytes()
+
+		if end > 0 {
+			l := s.Lines[end-1]
+			if len(l.Break) == 0 {
+				head = append(head, lb...)
+			}
+			if len(bytes.TrimSpace(l.Content)) > 0 {
+				head = append(head, lb...)
+			}
+		}
+	}
+
+	msg := make([]byte, 0, len(head)+len(block)+len(tail))
+	msg = append(msg, head...)
+	msg = append(msg, block...)
+	msg = append(msg, tail...)
+
+	return NewRawMessage(msg)
+}
+
+// lineBreak returns the first line break used in the message, defaulting to
+// "\n" when the message is a single line.
+func (s *RawMessage) lineBreak() []byte {
+	for _, l := range s.Lines {
+		if len(l.Break) > 0 {
+			return l.Break
+		}
+	}
+
+	return []byte{lf}
+}
+
+// items groups the given lines into trailers and their continuation lines.
+// Lines which are not part of a trailer are returned as items with an empty
+// Token.
+func (s *TrailerParser) items(lines Lines) []*Trailer {
+	r := []*Trailer{}
+
+	var last *Trailer
+	for _, line := range lines {
+		if last != nil && len(line.Content) > 0 &&
+			(line.Content[0] == ' ' || line.Content[0] == '\t') {
+			last.Lines = append(last.Lines, line)
+			last.Value = strings.TrimSpace(
+				last.Value + " " + string(bytes.TrimSpace(line.Content)),
+			)
+
+			continue
+		}
+
+		if len(line.Content) > 0 && line.Content[0] == commentChar {
+			continue
+		}
+
+		item := s.parse(line.Content)
+		item.Lines = Lines{line}
+		r = append(r, item)
+
+		last = nil
+		if item.Token != "" {
+			last = item
+		}
+	}
+
+	return r
+}
+
+// parse parses a single line into a Trailer. If the line is not a trailer, the
+// returned Trailer has an empty Token.
+func (s *TrailerParser) parse(content []byte) *Trailer {
+	token := s.tokenLength(content)
+	if token == 0 {
+		return &Trailer{}
+	}
+
+	rest := content[token:]
+	i := 0
+	for i < len(rest) && (rest[i] == ' ' || rest[i] == '\t') {
+		i++
+	}
+
+	if i >= len(rest) {
+		return &Trailer{}
+	}
+
+	separators := s.Separators
+	if separators == "" {
+		separators = ":"
+	}
+	if strings.IndexByte(separators, rest[i]) == -1 {
+		return &Trailer{}
+	}
+
+	sep := string(rest[i])
+	value := rest[i+1:]
+	if sep == "#" {
+		value = rest[i:]
+	}
+
+	return &Trailer{
+		Token:     string(content[:token]),
+		Separator: sep,
+		Value:     string(bytes.TrimSpace(value)),
+	}
+}
+
+// tokenLength returns the length of the trailer token at the start of
+// content, or zero if there is none. Tokens consist of alphanumeric characters
+// and "-", or are one of the parser's additional Tokens.
+func (s *TrailerParser) tokenLength(content []byte) int {
+	for _, token := range s.Tokens {
+		if bytes.HasPrefix(content, []byte(token)) {
+			return len(token)
+		}
+	}
+
+	i := 0
+	for i < len(content) && isTokenByte(content[i]) {
+		i++
+	}
+
+	return i
+}
+
+func isTokenByte(c byte) bool {
+	return c == '-' ||
+		(c >= '0' && c <= '9') ||
+		(c >= 'a' && c <= 'z') ||
+		(c >= 'A' && c <= 'Z')
+}
+
+// applyTrailerEdit applies a single edit to the given trailer block items
+// following the algorithm used by git interpret-trailers.
+// New trailers are written with the given separator.
+func applyTrailerEdit(
+	items []*Trailer,
+	edit *TrailerEdit,
+	separator string,
+) []*Trailer {
+	arg := &Trailer{Token: edit.Token, Separator: separator, Value: edit.Value}
+
+	if len(items) > 0 {
+		start := 0
+		if edit.Where.afterOrEnd() {
+			start = len(items) - 1
+		}
+
+		for n := 0; n < len(items); n++ {
+			i := n
+			if edit.Where.afterOrEnd() {
+				i = len(items) - 1 - n
+			}
+
+			if !strings.EqualFold(items[i].Token, arg.Token) {
+				continue
+			}
+
+			on := start
+			if edit.Where.middle() {
+				on = i
+			}
+
+			return applyTrailerIfExists(items, arg, i, on, edit)
+		}
+	}
+
+	if edit.IfMissing == IfMissingDoNothing {
+		return items
+	}
+
+	if edit.Where.afterOrEnd() {
+		return append(items, arg)
+	}
+
+	return append([]*Trailer{arg}, items...)
+}
+
+func applyTrailerIfExists(
+	items []*Trailer,
+	arg *Trailer,
+	in, on int,
+	edit *TrailerEdit,
+) []*Trailer {
+	switch edit.IfExists {
+	case IfExistsDoNothing:
+		return items
+	case IfExistsReplace:
+		items = insertTrailer(items, arg, on, edit.Where)
+		if on < in || (on == in && !edit.Where.afterOrEnd()) {
+			// The existing trailer was shifted by the insertion.
+			in++
+		}
+
+		return append(items[:in], items[in+1:]...)
+	case IfExistsAdd:
+		return insertTrailer(items, arg, on, edit.Where)
+	case IfExistsAddIfDifferent:
+		if !trailerIsDifferent(items, arg, in, true, edit.Where) {
+			return items
+		}
+
+		return insertTrailer(items, arg, on, edit.Where)
+	case IfExistsAddIfDifferentNeighbor:
+		if !trailerIsDifferent(items, arg, on, false, edit.Where) {
+			return items
+		}
+
+		return insertTrailer(items, arg, on, edit.Where)
+	}
+
+	return items
+}
+
+// trailerIsDifferent reports if arg differs from the item at index i. When all
+// is true, every item from i towards the start (for WhereEnd and WhereAfter) or
+// end (for WhereStart and WhereBefore) of the trailer block is also checked.
+func trailerIsDifferent(
+	items []*Trailer,
+	arg *Trailer,
+	i int,
+	all bool,
+	where Where,
+) bool {
+	for i >= 0 && i < len(items) {
+		if strings.EqualFold(items[i].Token, arg.Token) &&
+			strings.EqualFold(items[i].Value, arg.Value) {
+			return false
+		}
+
+		if !all {
+			break
+		}
+
+		if where.afterOrEnd() {
+			i--
+		} else {
+			i++
+		}
+	}
+
+	return true
+}
+
+// insertTrailer inserts arg after (for WhereEnd and WhereAfter) or before (for
+// WhereStart and WhereBefore) the item at index on.
+func insertTrailer(
+	items []*Trailer,
+	arg *Trailer,
+	on int,
+	where Where,
+) []*Trailer {
+	if where.afterOrEnd() {
+		on++
+	}
+
+	r := make([]*Trailer, 0, len(items)+1)
+	r = append(r, items[:on]...)
+	r = append(r, arg)
+	r = append(r, items[on:]...)
+
+	return r
+}
@@ -0,0 +1,599 @@
+package conventionalcommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRawMessage_Trailers(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []*Trailer
+	}{
+		{
+			name:    "empty",
+			message: "",
+			want:    []*Trailer{},
+		},
+		{
+			name:    "subject only",
+			message: "Signed-off-by: John Smith <john@example.com>",
+			want:    []*Trailer{},
+		},
+		{
+			name:    "subject and body",
+			message: "fix: a broken thing\n\nIt is now fixed.",
+			want:    []*Trailer{},
+		},
+		{
+			name:    "after patch divider",
+			message: "fix: a broken thing\n\nIt is now fixed.\n---\nRefs: #1\n",
+			want:    []*Trailer{},
+		},
+		{
+			name: "single trailer",
+			message: "fix: a broken thing\n\n" +
+				"Signed-off-by: John Smith <john@example.com>",
+			want: []*Trailer{
+				{
+					Token:     "Signed-off-by",
+					Separator: ":",
+					Value:     "John Smith <john@example.com>",
+					Lines: Lines{
+						{
+							Number: 3,
+							Content: []byte(
+								"Signed-off-by: John Smith <john@example.com>",
+							),
+							Break: []byte{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "conventional footer forms",
+			message: "feat: a new thing\n\n" +
+				"BREAKING CHANGE: it is different\n" +
+				"Refs #123",
+			want: []*Trailer{},
+		},
+		{
+			name: "continuation lines",
+			message: "feat: a new thing\n\n" +
+				"Notes: it is\n" +
+				"  very different",
+			want: []*Trailer{
+				{
+					Token:     "Notes",
+					Separator: ":",
+					Value:     "it is very different",
+					Lines: Lines{
+						{
+							Number:  3,
+							Content: []byte("Notes: it is"),
+							Break:   []byte("\n"),
+						},
+						{
+							Number:  4,
+							Content: []byte("  very different"),
+							Break:   []byte{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "non-trailer lines without Signed-off-by",
+			message: "fix: a broken thing\n\n" +
+				"Reviewed-by: Jane Doe <jane@example.com>\n" +
+				"This is not a trailer.",
+			want: []*Trailer{},
+		},
+		{
+			name: "non-trailer lines with Signed-off-by",
+			message: "fix: a broken thing\n\n" +
+				"(cherry picked from commit abc123)\n" +
+				"Signed-off-by: John Smith <john@example.com>",
+			want: []*Trailer{
+				{
+					Token:     "Signed-off-by",
+					Separator: ":",
+					Value:     "John Smith <john@example.com>",
+					Lines: Lines{
+						{
+							Number: 4,
+							Content: []byte(
+								"Signed-off-by: John Smith <john@example.com>",
+							),
+							Break: []byte{},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewRawMessage([]byte(tt.message))
+
+			got := msg.Trailers()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRawMessage_Footers(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    []*Trailer
+	}{
+		{
+			name:    "subject only",
+			message: "BREAKING CHANGE: it is different",
+			want:    []*Trailer{},
+		},
+		{
+			name: "hash separator and breaking change",
+			message: "feat: a new thing\n\n" +
+				"BREAKING CHANGE: it is different\n" +
+				"Refs #123",
+			want: []*Trailer{
+				{
+					Token:     "BREAKING CHANGE",
+					Separator: ":",
+					Value:     "it is different",
+					Lines: Lines{
+						{
+							Number:  3,
+							Content: []byte("BREAKING CHANGE: it is different"),
+							Break:   []byte("\n"),
+						},
+					},
+				},
+				{
+					Token:     "Refs",
+					Separator: "#",
+					Value:     "#123",
+					Lines: Lines{
+						{
+							Number:  4,
+							Content: []byte("Refs #123"),
+							Break:   []byte{},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "continuation lines",
+			message: "feat: a new thing\n\n" +
+				"BREAKING CHANGE: it is\n" +
+				"  very different",
+			want: []*Trailer{
+				{
+					Token:     "BREAKING CHANGE",
+					Separator: ":",
+					Value:     "it is very different",
+					Lines: Lines{
+						{
+							Number:  3,
+							Content: []byte("BREAKING CHANGE: it is"),
+							Break:   []byte("\n"),
+						},
+						{
+							Number:  4,
+							Content: []byte("  very different"),
+							Break:   []byte{},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewRawMessage([]byte(tt.message))
+
+			got := msg.Footers()
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRawMessage_EditTrailers(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		edits   []*TrailerEdit
+		want    string
+	}{
+		{
+			name:    "empty",
+			message: "",
+			edits:   []*TrailerEdit{{Token: "Signed-off-by", Value: "A"}},
+			want:    "Signed-off-by: A\n",
+		},
+		{
+			name:    "subject only",
+			message: "fix: a broken thing\n",
+			edits:   []*TrailerEdit{{Token: "Signed-off-by", Value: "A"}},
+			want:    "fix: a broken thing\n\nSigned-off-by: A\n",
+		},
+		{
+			name:    "subject and body",
+			message: "fix: a broken thing\n\nIt is now fixed.",
+			edits:   []*TrailerEdit{{Token: "Signed-off-by", Value: "A"}},
+			want: "fix: a broken thing\n\nIt is now fixed.\n\n" +
+				"Signed-off-by: A\n",
+		},
+		{
+			name:    "CRLF line breaks",
+			message: "fix: a broken thing\r\n\r\nReviewed-by: B\r\n",
+			edits:   []*TrailerEdit{{Token: "Signed-off-by", Value: "A"}},
+			want: "fix: a broken thing\r\n\r\nReviewed-by: B\r\n" +
+				"Signed-off-by: A\r\n",
+		},
+		{
+			name:    "addIfDifferentNeighbor with same neighbor",
+			message: "fix: a broken thing\n\nSigned-off-by: A",
+			edits:   []*TrailerEdit{{Token: "signed-off-by", Value: "a"}},
+			want:    "fix: a broken thing\n\nSigned-off-by: A\n",
+		},
+		{
+			name:    "addIfDifferentNeighbor with different neighbor",
+			message: "fix: a broken thing\n\nSigned-off-by: A\nReviewed-by: B",
+			edits:   []*TrailerEdit{{Token: "Signed-off-by", Value: "A"}},
+			want: "fix: a broken thing\n\nSigned-off-by: A\nReviewed-by: B\n" +
+				"Signed-off-by: A\n",
+		},
+		{
+			name:    "addIfDifferent with existing",
+			message: "fix: a broken thing\n\nSigned-off-by: A\nReviewed-by: B",
+			edits: []*TrailerEdit{
+				{
+					Token:    "Signed-off-by",
+					Value:    "A",
+					IfExists: IfExistsAddIfDifferent,
+				},
+			},
+			want: "fix: a broken thing\n\nSigned-off-by: A\nReviewed-by: B\n",
+		},
+		{
+			name:    "addIfDifferent with different",
+			message: "fix: a broken thing\n\nSigned-off-by: A\nReviewed-by: B",
+			edits: []*TrailerEdit{
+				{
+					Token:    "Signed-off-by",
+					Value:    "C",
+					IfExists: IfExistsAddIfDifferent,
+				},
+			},
+			want: "fix: a broken thing\n\nSigned-off-by: A\nReviewed-by: B\n" +
+				"Signed-off-by: C\n",
+		},
+		{
+			name:    "add",
+			message: "fix: a broken thing\n\nSigned-off-by: A",
+			edits: []*TrailerEdit{
+				{Token: "Signed-off-by", Value: "A", IfExists: IfExistsAdd},
+			},
+			want: "fix: a broken thing\n\nSigned-off-by: A\nSigned-off-by: A\n",
+		},
+		{
+			name:    "replace at end",
+			message: "fix: a broken thing\n\nSigned-off-by: A\nReviewed-by: B",
+			edits: []*TrailerEdit{
+				{
+					Token:    "Signed-off-by",
+					Value:    "C",
+					IfExists: IfExistsReplace,
+				},
+			},
+			want: "fix: a broken thing\n\nReviewed-by: B\nSigned-off-by: C\n",
+		},
+		{
+			name:    "replace after",
+			message: "fix: a broken thing\n\nSigned-off-by: A\nReviewed-by: B",
+			edits: []*TrailerEdit{
+				{
+					Token:    "Signed-off-by",
+					Value:    "C",
+					Where:    WhereAfter,
+					IfExists: IfExistsReplace,
+				},
+			},
+			want: "fix: a broken thing\n\nSigned-off-by: C\nReviewed-by: B\n",
+		},
+		{
+			name:    "replace before",
+			message: "fix: a broken thing\n\nReviewed-by: B\nSigned-off-by: A",
+			edits: []*TrailerEdit{
+				{
+					Token:    "Signed-off-by",
+					Value:    "C",
+					Where:    WhereBefore,
+					IfExists: IfExistsReplace,
+				},
+			},
+			want: "fix: a broken thing\n\nReviewed-by: B\nSigned-off-by: C\n",
+		},
+		{
+			name:    "doNothing",
+			message: "fix: a broken thing\n\nSigned-off-by: A",
+			edits: []*TrailerEdit{
+				{
+					Token:    "Signed-off-by",
+					Value:    "C",
+					IfExists: IfExistsDoNothing,
+				},
+			},
+			want: "fix: a broken thing\n\nSigned-off-by: A\n",
+		},
+		{
+			name:    "ifMissing doNothing",
+			message: "fix: a broken thing\n\nSigned-off-by: A",
+			edits: []*TrailerEdit{
+				{
+					Token:     "Reviewed-by",
+					Value:     "B",
+					IfMissing: IfMissingDoNothing,
+				},
+			},
+			want: "fix: a broken thing\n\nSigned-off-by: A\n",
+		},
+		{
+			name:    "missing at start",
+			message: "fix: a broken thing\n\nSigned-off-by: A",
+			edits: []*TrailerEdit{
+				{Token: "Reviewed-by", Value: "B", Where: WhereStart},
+			},
+			want: "fix: a broken thing\n\nReviewed-by: B\nSigned-off-by: A\n",
+		},
+		{
+			name:    "after existing token",
+			message: "fix: a broken thing\n\nRefs: #1\nSigned-off-by: A",
+			edits: []*TrailerEdit{
+				{Token: "Refs", Value: "#2", Where: WhereAfter},
+			},
+			want: "fix: a broken thing\n\nRefs: #1\nRefs: #2\n" +
+				"Signed-off-by: A\n",
+		},
+		{
+			name:    "multiple edits",
+			message: "fix: a broken thing",
+			edits: []*TrailerEdit{
+				{Token: "Refs", Value: "#1"},
+				{Token: "Signed-off-by", Value: "A"},
+			},
+			want: "fix: a broken thing\n\nRefs: #1\nSigned-off-by: A\n",
+		},
+		{
+			// Output of git interpret-trailers --trailer "Refs: #1".
+			name:    "conventional footers are not trailers",
+			message: "fix: a broken thing\n\nBREAKING CHANGE: foo\n",
+			edits:   []*TrailerEdit{{Token: "Refs", Value: "#1"}},
+			want: "fix: a broken thing\n\nBREAKING CHANGE: foo\n\n" +
+				"Refs: #1\n",
+		},
+		{
+			// Output of git interpret-trailers --trailer "Signed-off-by: A".
+			name: "hash separator is not a trailer",
+			message: "feat: a new thing\n\n" +
+				"BREAKING CHANGE: foo\nCloses #12\n",
+			edits: []*TrailerEdit{{Token: "Signed-off-by", Value: "A"}},
+			want: "feat: a new thing\n\n" +
+				"BREAKING CHANGE: foo\nCloses #12\n\n" +
+				"Signed-off-by: A\n",
+		},
+		{
+			// Output of git interpret-trailers --trailer "Reviewed-by: B".
+			name: "hash separator with Signed-off-by",
+			message: "fix: a broken thing\n\n" +
+				"Closes #12\nSigned-off-by: A\n",
+			edits: []*TrailerEdit{{Token: "Reviewed-by", Value: "B"}},
+			want: "fix: a broken thing\n\n" +
+				"Closes #12\nSigned-off-by: A\nReviewed-by: B\n",
+		},
+		{
+			// Output of git interpret-trailers --trailer "Refs: #1".
+			name:    "whitespace before separator",
+			message: "fix: a broken thing\n\nRefs : #12\n",
+			edits:   []*TrailerEdit{{Token: "Refs", Value: "#1"}},
+			want:    "fix: a broken thing\n\nRefs : #12\nRefs: #1\n",
+		},
+		{
+			name: "retains continuation and non-trailer lines",
+			message: "fix: a broken thing\n\n" +
+				"(cherry picked from commit abc123)\n" +
+				"Signed-off-by: A\n" +
+				"Reviewed-by: B\n" +
+				"  and C\n",
+			edits: []*TrailerEdit{{Token: "Signed-off-by", Value: "D"}},
+			want: "fix: a broken thing\n\n" +
+				"(cherry picked from commit abc123)\n" +
+				"Signed-off-by: A\n" +
+				"Reviewed-by: B\n" +
+				"  and C\n" +
+				"Signed-off-by: D\n",
+		},
+		{
+			// Output of git interpret-trailers --trailer "Reviewed-by: B".
+			name:    "subject only without line break",
+			message: "fix: a broken thing",
+			edits:   []*TrailerEdit{{Token: "Reviewed-by", Value: "B"}},
+			want:    "fix: a broken thing\n\nReviewed-by: B\n",
+		},
+		{
+			// Output of git interpret-trailers --trailer "Reviewed-by: B".
+			name:    "trailing whitespace in body",
+			message: "fix: a broken thing\n\nIt is now fixed.   \n",
+			edits:   []*TrailerEdit{{Token: "Reviewed-by", Value: "B"}},
+			want: "fix: a broken thing\n\nIt is now fixed.   \n\n" +
+				"Reviewed-by: B\n",
+		},
+		{
+			// Output of git interpret-trailers --trailer "Reviewed-by: B".
+			name: "trailing comments",
+			message: "fix: a broken thing\n\nSigned-off-by: A\n\n" +
+				"# Please enter the commit message for your changes.\n" +
+				"#\n# On branch main\n",
+			edits: []*TrailerEdit{{Token: "Reviewed-by", Value: "B"}},
+			want: "fix: a broken thing\n\nSigned-off-by: A\n" +
+				"Reviewed-by: B\n\n" +
+				"# Please enter the commit message for your changes.\n" +
+				"#\n# On branch main\n",
+		},
+		{
+			// Output of git interpret-trailers --trailer "Reviewed-by: B".
+			name: "comments within trailer block",
+			message: "fix: a broken thing\n\n" +
+				"Signed-off-by: A\n# c\nSigned-off-by: C\n",
+			edits: []*TrailerEdit{{Token: "Reviewed-by", Value: "B"}},
+			want: "fix: a broken thing\n\n" +
+				"Signed-off-by: A\nSigned-off-by: C\nReviewed-by: B\n",
+		},
+		{
+			// Output of git interpret-trailers --trailer "Reviewed-by: B".
+			name: "patch divider",
+			message: "fix: a broken thing\n\n" +
+				"Signed-off-by: A\n---\n a.go | 1 +\n",
+			edits: []*TrailerEdit{{Token: "Reviewed-by", Value: "B"}},
+			want: "fix: a broken thing\n\nSigned-off-by: A\nReviewed-by: B\n" +
+				"---\n a.go | 1 +\n",
+		},
+		{
+			// Output of git interpret-trailers --trailer "Reviewed-by: B".
+			name: "patch divider after blank line",
+			message: "fix: a broken thing\n\nIt is now fixed.\n\n" +
+				"---\nSigned-off-by: A\n",
+			edits: []*TrailerEdit{{Token: "Reviewed-by", Value: "B"}},
+			want: "fix: a broken thing\n\nIt is now fixed.\n\n" +
+				"Reviewed-by: B\n\n---\nSigned-off-by: A\n",
+		},
+		{
+			// Output of git interpret-trailers --trailer "Reviewed-by: B".
+			name: "scissors",
+			message: "fix: a broken thing\n\nIt is now fixed.\n" +
+				"# ------------------------ >8 ------------------------\n" +
+				"diff --git a/a.go b/a.go\n",
+			edits: []*TrailerEdit{{Token: "Reviewed-by", Value: "B"}},
+			want: "fix: a broken thing\n\nIt is now fixed.\n\n" +
+				"Reviewed-by: B\n" +
+				"# ------------------------ >8 ------------------------\n" +
+				"diff --git a/a.go b/a.go\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewRawMessage([]byte(tt.message))
+
+			got := msg.EditTrailers(tt.edits...)
+
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestRawMessage_RemoveTrailers(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		token   string
+		want    string
+	}{
+		{
+			name:    "no trailers",
+			message: "fix: a broken thing\n\nIt is now fixed.\n",
+			token:   "Signed-off-by",
+			want:    "fix: a broken thing\n\nIt is now fixed.\n",
+		},
+		{
+			name:    "some trailers",
+			message: "fix: a broken thing\n\nSigned-off-by: A\nRefs: #1\n",
+			token:   "signed-off-by",
+			want:    "fix: a broken thing\n\nRefs: #1\n",
+		},
+		{
+			name: "all trailers",
+			message: "fix: a broken thing\n\n" +
+				"Signed-off-by: A\nsigned-off-by: B\n",
+			token: "Signed-off-by",
+			want:  "fix: a broken thing\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewRawMessage([]byte(tt.message))
+
+			got := msg.RemoveTrailers(tt.token)
+
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestTrailerParser_EditTrailers(t *testing.T) {
+	tests := []struct {
+		name    string
+		parser  *TrailerParser
+		message string
+		edits   []*TrailerEdit
+		want    string
+	}{
+		{
+			// Output of git -c trailer.separators=:# interpret-trailers
+			// --trailer "Refs: #1", except that git rewrites the existing
+			// trailer as "Closes: 12".
+			name:    "hash separator",
+			parser:  &TrailerParser{Separators: ":#"},
+			message: "fix: a broken thing\n\nCloses #12\n",
+			edits:   []*TrailerEdit{{Token: "Refs", Value: "#1"}},
+			want:    "fix: a broken thing\n\nCloses #12\nRefs: #1\n",
+		},
+		{
+			name:    "new trailers use the first separator",
+			parser:  &TrailerParser{Separators: "=:"},
+			message: "fix: a broken thing\n\nCloses: #12\n",
+			edits:   []*TrailerEdit{{Token: "Refs", Value: "#1"}},
+			want:    "fix: a broken thing\n\nCloses: #12\nRefs= #1\n",
+		},
+		{
+			name: "additional tokens",
+			parser: &TrailerParser{
+				Separators: ":#",
+				Tokens:     []string{"BREAKING CHANGE"},
+			},
+			message: "feat: a new thing\n\n" +
+				"BREAKING CHANGE: foo\nCloses #12\n",
+			edits: []*TrailerEdit{{Token: "Signed-off-by", Value: "A"}},
+			want: "feat: a new thing\n\n" +
+				"BREAKING CHANGE: foo\nCloses #12\nSigned-off-by: A\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := NewRawMessage([]byte(tt.message))
+
+			got := tt.parser.EditTrailers(msg, tt.edits...)
+
+			assert.Equal(t, tt.want, got.String())
+		})
+	}
+}
+
+func TestTrailerParser_RemoveTrailers(t *testing.T) {
+	parser := &TrailerParser{Separators: ":#"}
+	msg := NewRawMessage([]byte(
+		"fix: a broken thing\n\nCloses #12\nRefs: #1\n",
+	))
+
+	got := parser.RemoveTrailers(msg, "closes")
+
+	assert.Equal(t, "fix: a broken thing\n\nRefs: #1\n", got.String())
+}
@@ -0,0 +1,270 @@
+package conventionalcommit
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Bump is the semantic versioning impact of a change.
+type Bump int
+
+const (
+	// BumpNone does not require a new version.
+	BumpNone Bump = iota
+
+	// BumpPatch requires a new patch version.
+	BumpPatch
+
+	// BumpMinor requires a new minor version.
+	BumpMinor
+
+	// BumpMajor requires a new major version.
+	BumpMajor
+)
+
+// String returns a lowercase name for the bump level.
+func (b Bump) String() string {
+	switch b {
+	case BumpNone:
+		return "none"
+	case BumpPatch:
+		return "patch"
+	case BumpMinor:
+		return "minor"
+	case BumpMajor:
+		return "major"
+	}
+
+	return "unknown"
+}
+
+// UnmarshalText parses a bump level from its lowercase name, allowing bump
+// levels to be read from configuration files.
+func (b *Bump) UnmarshalText(text []byte) error {
+	for _, v := range []Bump{BumpNone, BumpPatch, BumpMinor, BumpMajor} {
+		if v.String() == string(text) {
+			*b = v
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown bump level %q", text)
+}
+
+// Type describes a single commit type, like "feat" or "fix".
+type Type struct {
+	// Name is the type as written in commit message headers.
+	Name string
+
+	// Aliases is a list of common alternative names for the type, like
+	// "feature" for "feat", which are suggested as corrections.
+	Aliases []string
+
+	// Description is a short human readable description of when the type
+	// should be used.
+	Description string
+
+	// Title is the title of the changelog section listing commits of this
+	// type.
+	Title string
+
+	// Hidden indicates that commits of this type are not listed in
+	// changelogs, unless they contain breaking changes.
+	Hidden bool
+
+	// Bump is the semantic versioning impact of commits of this type, when
+	// they do not contain breaking changes.
+	Bump Bump
+}
+
+// TypeRegistry is an ordered collection of commit types. Types are looked up
+// case-insensitively, as conventional commit types are not case sensitive.
+type TypeRegistry struct {
+	types []*Type
+	index map[string]*Type
+}
+
+// NewTypeRegistry returns a TypeRegistry containing the given types, in the
+// given order.
+func NewTypeRegistry(types ...*Type) *TypeRegistry {
+	r := &TypeRegistry{
+		types: []*Type{},
+		index: map[string]*Type{},
+	}
+
+	for _, t := range types {
+		r.Add(t)
+	}
+
+	return r
+}
+
+// DefaultTypeRegistry returns a new TypeRegistry containing the Angular
+// preset's types.
+func DefaultTypeRegistry() *TypeRegistry {
+	return NewTypeRegistry(AngularTypes()...)
+}
+
+// AngularTypes returns the commit types of the Angular convention, as used by
+// commitlint's config-conventional, with bump levels matching the default
+// release rules of semantic-release.
+func AngularTypes() []*Type {
+	return []*Type{
+		{
+			Name:        "feat",
+			Aliases:     []string{"feature", "features"},
+			Description: "A new feature",
+			Title:       "Features",
+			Bump:        BumpMinor,
+		},
+		{
+			Name:        "fix",
+			Aliases:     []string{"bugfix", "bug", "hotfix", "fixes"},
+			Description: "A bug fix",
+			Title:       "Bug Fixes",
+			Bump:        BumpPatch,
+		},
+		{
+			Name:        "perf",
+			Aliases:     []string{"performance", "optimize"},
+			Description: "A code change that improves performance",
+			Title:       "Performance Improvements",
+			Bump:        BumpPatch,
+		},
+		{
+			Name:        "revert",
+			Aliases:     []string{"reverts", "rollback"},
+			Description: "Reverts a previous commit",
+			Title:       "Reverts",
+			Bump:        BumpPatch,
+		},
+		{
+			Name:        "docs",
+			Aliases:     []string{"doc", "documentation"},
+			Description: "Documentation only changes",
+			Title:       "Documentation",
+			Hidden:      true,
+		},
+		{
+			Name:    "style",
+			Aliases: []string{"styles", "format", "formatting"},
+			Description: "Changes that do not affect the meaning of the code " +
+				"(white-space, formatting, missing semi-colons, etc)",
+			Title:  "Styles",
+			Hidden: true,
+		},
+		{
+			Name:    "refactor",
+			Aliases: []string{"refactoring"},
+			Description: "A code change that neither fixes a bug nor adds " +
+				"a feature",
+			Title:  "Code Refactoring",
+			Hidden: true,
+		},
+		{
+			Name:        "test",
+			Aliases:     []string{"tests", "testing"},
+			Description: "Adding missing tests or correcting existing tests",
+			Title:       "Tests",
+			Hidden:      true,
+		},
+		{
+			Name:    "build",
+			Aliases: []string{"builds"},
+			Description: "Changes that affect the build system or external " +
+				"dependencies",
+			Title:  "Builds",
+			Hidden: true,
+		},
+		{
+			Name:        "ci",
+			Description: "Changes to CI configuration files and scripts",
+			Title:       "Continuous Integration",
+			Hidden:      true,
+		},
+		{
+			Name:        "chore",
+			Aliases:     []string{"chores"},
+			Description: "Other changes that don't modify src or test files",
+			Title:       "Chores",
+			Hidden:      true,
+		},
+	}
+}
+
+// Add adds the given type to the registry. If a type with the same name
+// already exists, it is replaced in place.
+func (s *TypeRegistry) Add(t *Type) {
+	key := strings.ToLower(t.Name)
+
+	if _, ok := s.index[key]; ok {
+		for i, existing := range s.types {
+			if strings.EqualFold(existing.Name, t.Name) {
+				s.types[i] = t
+			}
+		}
+	} else {
+		s.types = append(s.types, t)
+	}
+
+	s.index[key] = t
+}
+
+// Remove removes the type with the given name from the registry, if it exists.
+func (s *TypeRegistry) Remove(name string) {
+	key := strings.ToLower(name)
+
+	if _, ok := s.index[key]; !ok {
+		return
+	}
+
+	delete(s.index, key)
+	for i, t := range s.types {
+		if strings.EqualFold(t.Name, name) {
+			s.types = append(s.types[:i], s.types[i+1:]...)
+
+			break
+		}
+	}
+}
+
+// Get returns the type with the given name, and false if it does not exist.
+func (s *TypeRegistry) Get(name string) (*Type, bool) {
+	t, ok := s.index[strings.ToLower(name)]
+
+	return t, ok
+}
+
+// Types returns all types in the registry, in the order they were added.
+func (s *TypeRegistry) Types() []*Type {
+	r := make([]*Type, len(s.types))
+	copy(r, s.types)
+
+	return r
+}
+
+// Names returns the names of all types in the registry, in the order they were
+// added.
+func (s *TypeRegistry) Names() []string {
+	r := make([]string, 0, len(s.types))
+	for _, t := range s.types {
+		r = append(r, t.Name)
+	}
+
+	return r
+}
+
+// Bump returns the semantic versioning impact of a commit with the given type.
+// Breaking changes are always a major bump, and unknown types have no impact.
+func (s *TypeRegistry) Bump(name string, breaking bool) Bump {
+	if breaking {
+		return BumpMajor
+	}
+
+	if t, ok := s.Get(name); ok {
+		return t.Bump
+	}
+
+	return BumpNone
+}
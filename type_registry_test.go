@@ -0,0 +1,120 @@
+package conventionalcommit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBump_String(t *testing.T) {
+	tests := []struct {
+		bump Bump
+		want string
+	}{
+		{bump: BumpNone, want: "none"},
+		{bump: BumpPatch, want: "patch"},
+		{bump: BumpMinor, want: "minor"},
+		{bump: BumpMajor, want: "major"},
+		{bump: Bump(99), want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.bump.String())
+		})
+	}
+}
+
+func TestDefaultTypeRegistry(t *testing.T) {
+	r := DefaultTypeRegistry()
+
+	assert.Equal(t,
+		[]string{
+			"feat", "fix", "perf", "revert", "docs", "style", "refactor",
+			"test", "build", "ci", "chore",
+		},
+		r.Names(),
+	)
+
+	feat, ok := r.Get("FEAT")
+	assert.True(t, ok)
+	assert.Equal(t, "Features", feat.Title)
+	assert.False(t, feat.Hidden)
+
+	docs, ok := r.Get("docs")
+	assert.True(t, ok)
+	assert.Equal(t, "Documentation", docs.Title)
+	assert.True(t, docs.Hidden)
+
+	// Each call returns a new registry.
+	r.Remove("feat")
+	_, ok = DefaultTypeRegistry().Get("feat")
+	assert.True(t, ok)
+}
+
+func TestTypeRegistry_Add(t *testing.T) {
+	r := NewTypeRegistry(
+		&Type{Name: "feat", Bump: BumpMinor},
+		&Type{Name: "fix", Bump: BumpPatch},
+	)
+
+	r.Add(&Type{Name: "deps", Title: "Dependencies", Bump: BumpPatch})
+	r.Add(&Type{Name: "Feat", Title: "New Stuff", Bump: BumpMinor})
+
+	assert.Equal(t, []string{"Feat", "fix", "deps"}, r.Names())
+
+	feat, ok := r.Get("feat")
+	assert.True(t, ok)
+	assert.Equal(t, "New Stuff", feat.Title)
+}
+
+func TestTypeRegistry_Remove(t *testing.T) {
+	r := NewTypeRegistry(
+		&Type{Name: "feat"},
+		&Type{Name: "fix"},
+		&Type{Name: "docs"},
+	)
+
+	r.Remove("FIX")
+	r.Remove("nope")
+
+	assert.Equal(t, []string{"feat", "docs"}, r.Names())
+	_, ok := r.Get("fix")
+	assert.False(t, ok)
+}
+
+func TestTypeRegistry_Types(t *testing.T) {
+	feat := &Type{Name: "feat"}
+	fix := &Type{Name: "fix"}
+	r := NewTypeRegistry(feat, fix)
+
+	got := r.Types()
+	got[0] = fix
+
+	assert.Equal(t, []*Type{feat, fix}, r.Types())
+}
+
+func TestTypeRegistry_Bump(t *testing.T) {
+	r := DefaultTypeRegistry()
+
+	tests := []struct {
+		name     string
+		typ      string
+		breaking bool
+		want     Bump
+	}{
+		{name: "feat", typ: "feat", want: BumpMinor},
+		{name: "fix", typ: "fix", want: BumpPatch},
+		{name: "perf", typ: "Perf", want: BumpPatch},
+		{name: "docs", typ: "docs", want: BumpNone},
+		{name: "unknown", typ: "nope", want: BumpNone},
+		{name: "breaking docs", typ: "docs", breaking: true, want: BumpMajor},
+		{name: "breaking unknown", typ: "", breaking: true, want: BumpMajor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := r.Bump(tt.typ, tt.breaking)
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}